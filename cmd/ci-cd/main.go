@@ -4,8 +4,11 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/kemadev/ci-cd/internal/config"
@@ -21,13 +24,43 @@ func main() {
 		os.Exit(1)
 	}
 
-	retCode, err := dispatch.Run(config, os.Args[1:])
+	args, err := config.ParseFlags(os.Args[1:])
+	if err != nil {
+		slog.Error("Failed to parse flags", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Linters are terminated on interruption, as they run in their own process group and would not get signals otherwise
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	retCode, err := dispatch.Run(ctx, config, args)
+	interrupted := ctx.Err() != nil
+
+	stop()
+
 	if err != nil {
 		slog.Error("Error executing command", slog.String("error", err.Error()))
 
 		retCode = 1
 	}
 
+	err = dispatch.WriteReports(config)
+	if err != nil {
+		slog.Error("Error writing reports", slog.String("error", err.Error()))
+
+		retCode = 1
+	}
+
+	// Fixes are not applied if interrupted, as user may not expect files to change anymore
+	if !interrupted {
+		err = dispatch.ApplyFixes(config)
+		if err != nil {
+			slog.Error("Error applying fixes", slog.String("error", err.Error()))
+
+			retCode = 1
+		}
+	}
+
 	slog.Debug("Execution time", slog.String("duration", time.Since(startTime).String()))
 
 	if retCode != 0 {
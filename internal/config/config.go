@@ -4,24 +4,119 @@
 package config
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kemadev/ci-cd/internal/auth"
+	"github.com/kemadev/ci-cd/internal/diff"
 	eauth "github.com/kemadev/ci-cd/pkg/auth"
+	"github.com/kemadev/ci-cd/pkg/ci"
+	"github.com/kemadev/go-framework/pkg/git"
 )
 
 type Config struct {
-	DebugEnabled bool
-	Logger       *slog.Logger
+	DebugEnabled  bool
+	SilentEnabled bool
+	Logger        *slog.Logger
+	// Findings output format, auto-detected from environment if empty
+	OutputFormat string
+	// Report files to write once all commands ran
+	Reports []ReportFile
+	// Findings of all commands ran, used to write reports
+	Findings *ci.Collector
+	// Known findings that are not to be reported
+	Baseline *ci.Baseline
+	// Path to write a baseline of all findings to, once all commands ran
+	BaselineWritePath string
+	// Lines changed since diff base, only findings related to them are reported if not nil
+	ChangedLines diff.ChangedLines
+	// Repository-level suppressions of findings, loaded once by the first command filtering findings, so that other ones
+	// do not depend on the suppressions file being valid
+	Suppressions func() (*ci.SuppressionsFile, error)
+	// Findings levels making commands fail, commands fail according to tools exit code if nil
+	FailOn []string
+	// Classes of equivalent rules, used to merge findings of different tools, loaded once when findings are first
+	// deduplicated, so that commands that do not do so do not depend on the rule equivalences file being valid
+	RuleEquivalences func() (*ci.RuleEquivalences, error)
+	// Only collect findings, for them to be printed once all commands ran
+	DeferPrinting bool
+	// Apply suggested fixes of reported findings once all commands ran
+	Fix bool
+	// Path to write suggested fixes of reported findings to as a unified diff, once all commands ran
+	FixDiffPath string
+	// Maximum duration of linter runs that do not set their own, 0 meaning no timeout
+	Timeout time.Duration
 }
 
+type ReportFile struct {
+	Format string
+	Path   string
+}
+
+var (
+	ErrInvalidReportFlag = fmt.Errorf("invalid report flag, expected format=path")
+	ErrInvalidFailOn     = fmt.Errorf("invalid fail-on level, expected comma-separated list of notice, warning, error")
+)
+
 const (
 	DefaultConfigPath = "/var/config/"
 	LocalConfigPath   = "./config/"
+	// Suppressions file path, relative to config directories
+	SuppressionsFilePath = "suppressions/.suppressions.yaml"
+	// Rule equivalences file path, relative to config directories
+	RuleEquivalencesFilePath = "dedup/.equivalences.yaml"
+	// Linter definitions directory path, relative to config directories
+	LinterDefinitionsDirPath = "linters.d"
+	// Tools config files paths, relative to config directories
+	GitleaksIgnoreFilePath = "gitleaks/.gitleaksignore"
+	GolangciLintFilePath   = "golangci-lint/.golangci.yaml"
+	GoreleaserFilePath     = "goreleaser/.goreleaser.yaml"
+	GrypeFilePath          = "grype/.grype.yaml"
+	MarkdownlintFilePath   = "markdownlint/.markdownlint.yaml"
+	SyftFilePath           = "syft/.syft.yaml"
+	// Pinned versions of tools file path, relative to config directories, generated from build/Dockerfile
+	ToolVersionsFilePath = "doctor/.versions.yaml"
+	// Default maximum duration of linter runs, so that hung tools do not block jobs until CI platforms kill them
+	DefaultTimeout = 30 * time.Minute
 )
 
+// Load suppressions file from config directories.
+func loadSuppressions() (*ci.SuppressionsFile, error) {
+	path, err := SelectFile(SuppressionsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting suppressions file: %w", err)
+	}
+
+	suppressions, err := ci.ReadSuppressionsFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading suppressions file: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// Load rule equivalences file from config directories.
+func loadRuleEquivalences() (*ci.RuleEquivalences, error) {
+	path, err := SelectFile(RuleEquivalencesFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting rule equivalences file: %w", err)
+	}
+
+	equivalences, err := ci.ReadRuleEquivalences(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading rule equivalences file: %w", err)
+	}
+
+	return equivalences, nil
+}
+
 func NewConfig() (*Config, error) {
 	var logLevel slog.Level
 
@@ -55,20 +150,196 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
-	logger := slog.New(
+	logger := newLogger(slogFd, logLevel, debugEnabled)
+	slog.SetDefault(logger)
+
+	failOn, err := parseFailOn(os.Getenv("RUNNER_FAIL_ON"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing RUNNER_FAIL_ON: %w", err)
+	}
+
+	timeout := DefaultTimeout
+
+	if value := os.Getenv("RUNNER_TIMEOUT"); value != "" {
+		timeout, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing RUNNER_TIMEOUT: %w", err)
+		}
+	}
+
+	return &Config{
+		DebugEnabled:      debugEnabled,
+		SilentEnabled:     silentEnabled,
+		Logger:            logger,
+		OutputFormat:      "",
+		Reports:           []ReportFile{},
+		Findings:          ci.NewCollector(),
+		Baseline:          nil,
+		BaselineWritePath: "",
+		ChangedLines:      nil,
+		Suppressions:      sync.OnceValues(loadSuppressions),
+		FailOn:            failOn,
+		RuleEquivalences:  sync.OnceValues(loadRuleEquivalences),
+		DeferPrinting:     false,
+		Fix:               false,
+		FixDiffPath:       "",
+		Timeout:           timeout,
+	}, nil
+}
+
+// Parse global flags, which are to be passed before the command name, returning remaining arguments.
+func (c *Config) ParseFlags(args []string) ([]string, error) {
+	flags := flag.NewFlagSet("kema-runner", flag.ContinueOnError)
+
+	flags.StringVar(
+		&c.OutputFormat,
+		"format",
+		c.OutputFormat,
+		"findings output format, one of human, json, github, gitlab, gitlab-codequality, sarif, junit, checkstyle, rdjson, markdown, html (default: auto-detected)",
+	)
+	flags.Func(
+		"report",
+		"write findings of all commands to a file once they ran, as format=path, can be repeated",
+		func(value string) error {
+			format, path, found := strings.Cut(value, "=")
+			if !found || format == "" || path == "" {
+				return fmt.Errorf("%s: %w", value, ErrInvalidReportFlag)
+			}
+
+			c.Reports = append(c.Reports, ReportFile{Format: format, Path: path})
+
+			return nil
+		},
+	)
+
+	flags.Func(
+		"baseline",
+		"only report findings that are not part of baseline file at given path",
+		func(value string) error {
+			baseline, err := ci.ReadBaseline(value)
+			if err != nil {
+				return fmt.Errorf("error loading baseline %s: %w", value, err)
+			}
+
+			c.Baseline = baseline
+
+			return nil
+		},
+	)
+	flags.StringVar(
+		&c.BaselineWritePath,
+		"baseline-write",
+		c.BaselineWritePath,
+		"write a baseline of all findings to given path, once all commands ran",
+	)
+
+	diffBase := flags.String(
+		"diff-base",
+		"",
+		"only report findings related to lines changed since merge base with given revision, e.g. origin/main",
+	)
+
+	flags.Func(
+		"fail-on",
+		"comma-separated findings levels making commands fail, among notice, warning, error (default: tools exit code)",
+		func(value string) error {
+			failOn, err := parseFailOn(value)
+			if err != nil {
+				return err
+			}
+
+			c.FailOn = failOn
+
+			return nil
+		},
+	)
+
+	flags.DurationVar(
+		&c.Timeout,
+		"timeout",
+		c.Timeout,
+		"maximum duration of linter runs that do not set their own, e.g. 10m, 0 meaning no timeout, also set by RUNNER_TIMEOUT",
+	)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing flags: %w", err)
+	}
+
+	// Logs would otherwise be mixed with the document printed to stdout
+	if ci.IsDocumentFormat(c.OutputFormat) && !c.SilentEnabled {
+		logLevel := slog.LevelInfo
+		if c.DebugEnabled {
+			logLevel = slog.LevelDebug
+		}
+
+		c.Logger = newLogger(os.Stderr, logLevel, c.DebugEnabled)
+		slog.SetDefault(c.Logger)
+	}
+
+	slog.Info("Start", slog.Bool("debug mode", c.DebugEnabled))
+
+	if *diffBase != "" {
+		changedLines, err := diff.GetChangedLines(git.NewGitService(), *diffBase)
+		if err != nil {
+			return nil, fmt.Errorf("error computing changed lines since %s: %w", *diffBase, err)
+		}
+
+		c.ChangedLines = changedLines
+	}
+
+	return flags.Args(), nil
+}
+
+func newLogger(w io.Writer, logLevel slog.Level, debugEnabled bool) *slog.Logger {
+	return slog.New(
 		slog.NewTextHandler(
-			slogFd,
+			w,
 			&slog.HandlerOptions{Level: logLevel, AddSource: debugEnabled, ReplaceAttr: nil},
 		),
 	)
-	slog.SetDefault(logger)
+}
 
-	slog.Info("Start", slog.Bool("debug mode", debugEnabled))
+// Parse comma-separated list of findings levels, returning nil for an empty value.
+func parseFailOn(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
 
-	return &Config{
-		DebugEnabled: debugEnabled,
-		Logger:       logger,
-	}, nil
+	levels := []string{}
+
+	for level := range strings.SplitSeq(value, ",") {
+		level = strings.ToLower(strings.TrimSpace(level))
+		if !slices.Contains([]string{"notice", "warning", "error"}, level) {
+			return nil, fmt.Errorf("%s: %w", level, ErrInvalidFailOn)
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+// Config files commands can not run without, relative to config directories.
+func RequiredFiles() []string {
+	return []string{
+		GitleaksIgnoreFilePath,
+		GolangciLintFilePath,
+		GoreleaserFilePath,
+		GrypeFilePath,
+		MarkdownlintFilePath,
+		SyftFilePath,
+		ToolVersionsFilePath,
+	}
+}
+
+// Config files which defaults apply to when missing, relative to config directories.
+func OptionalFiles() []string {
+	return []string{
+		SuppressionsFilePath,
+		RuleEquivalencesFilePath,
+		LinterDefinitionsDirPath,
+	}
 }
 
 // Select config file, priorizing local one over default one.
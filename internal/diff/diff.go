@@ -0,0 +1,186 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package diff
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v6/plumbing"
+	fdiff "github.com/go-git/go-git/v6/plumbing/format/diff"
+	"github.com/kemadev/ci-cd/pkg/ci"
+	kgit "github.com/kemadev/go-framework/pkg/git"
+)
+
+var ErrNoMergeBase = fmt.Errorf("no merge base found")
+
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ChangedLines holds, for each changed file, ranges of lines added or modified. Paths are relative to current working
+// directory, as findings paths are once normalized.
+type ChangedLines map[string][]LineRange
+
+// Compute lines changed between the merge base of HEAD and given base revision (typically the PR target branch), and HEAD.
+func GetChangedLines(gitSvc *kgit.Service, base string) (ChangedLines, error) {
+	repo, err := gitSvc.GetGitRepo()
+	if err != nil {
+		return nil, fmt.Errorf("error getting git repo: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD commit: %w", err)
+	}
+
+	baseHash, err := repo.ResolveRevision(plumbing.Revision(base))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving revision %s: %w", base, err)
+	}
+
+	baseCommit, err := repo.CommitObject(*baseHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting commit of %s: %w", base, err)
+	}
+
+	mergeBases, err := headCommit.MergeBase(baseCommit)
+	if err != nil {
+		return nil, fmt.Errorf("error computing merge base with %s: %w", base, err)
+	}
+
+	if len(mergeBases) == 0 || mergeBases[0] == nil {
+		return nil, fmt.Errorf("base %s: %w", base, ErrNoMergeBase)
+	}
+
+	slog.Debug("computing diff", slog.String("base", base), slog.String("mergeBase", mergeBases[0].Hash.String()))
+
+	patch, err := mergeBases[0].Patch(headCommit)
+	if err != nil {
+		return nil, fmt.Errorf("error computing patch: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting worktree: %w", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current working directory: %w", err)
+	}
+
+	changedLines := ChangedLines{}
+
+	for _, filePatch := range patch.FilePatches() {
+		_, to := filePatch.Files()
+		// File was deleted, no finding can point to it
+		if to == nil {
+			continue
+		}
+
+		path, err := filepath.Rel(cwd, filepath.Join(worktree.Filesystem.Root(), to.Path()))
+		if err != nil {
+			return nil, fmt.Errorf("error computing relative path of %s: %w", to.Path(), err)
+		}
+
+		changedLines[filepath.ToSlash(path)] = changedRanges(filePatch.Chunks())
+	}
+
+	return changedLines, nil
+}
+
+func countLines(content string) int {
+	count := strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		count++
+	}
+
+	return count
+}
+
+// Compute ranges of lines of the new file version that were added or modified.
+func changedRanges(chunks []fdiff.Chunk) []LineRange {
+	ranges := []LineRange{}
+	line := 1
+
+	for _, chunk := range chunks {
+		count := countLines(chunk.Content())
+
+		switch chunk.Type() {
+		case fdiff.Equal:
+			line += count
+		case fdiff.Add:
+			ranges = append(ranges, LineRange{Start: line, End: line + count - 1})
+			line += count
+		case fdiff.Delete:
+			// Deleted lines do not exist in new file version
+		}
+	}
+
+	return ranges
+}
+
+// Split normalized findings into the ones that relate to changed lines and the others. Findings that are not tied to
+// lines are kept as long as their file (or a file in their directory) changed, and findings that are not tied to an
+// existing file (repository-level findings such as PR title or stale branches) are always kept.
+func (c ChangedLines) Filter(findings []ci.Finding) ([]ci.Finding, []ci.Finding) {
+	var kept, dropped []ci.Finding
+
+	for _, finding := range findings {
+		if c.isRelated(finding) {
+			kept = append(kept, finding)
+		} else {
+			dropped = append(dropped, finding)
+		}
+	}
+
+	return kept, dropped
+}
+
+func (c ChangedLines) isRelated(finding ci.Finding) bool {
+	path := filepath.ToSlash(filepath.Clean(finding.FilePath))
+
+	ranges, changed := c[path]
+	if !changed {
+		info, err := os.Stat(finding.FilePath)
+		if err != nil {
+			return true
+		}
+
+		// Package-level findings, such as coverage ones, relate to any change in the package
+		if info.IsDir() {
+			for changedPath := range c {
+				if strings.HasPrefix(changedPath, path+"/") {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	if finding.StartLine <= 0 {
+		return true
+	}
+
+	endLine := max(finding.EndLine, finding.StartLine)
+
+	for _, r := range ranges {
+		if finding.StartLine <= r.End && endLine >= r.Start {
+			return true
+		}
+	}
+
+	return false
+}
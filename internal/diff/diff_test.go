@@ -0,0 +1,135 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	fdiff "github.com/go-git/go-git/v6/plumbing/format/diff"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+type chunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c chunk) Content() string {
+	return c.content
+}
+
+func (c chunk) Type() fdiff.Operation {
+	return c.op
+}
+
+func TestChangedRanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		chunks []fdiff.Chunk
+		want   []LineRange
+	}{
+		{
+			name: "added and modified lines",
+			chunks: []fdiff.Chunk{
+				chunk{content: "a\nb\n", op: fdiff.Equal},
+				chunk{content: "c\n", op: fdiff.Add},
+				chunk{content: "d\n", op: fdiff.Equal},
+				chunk{content: "old\nold\n", op: fdiff.Delete},
+				chunk{content: "new\nnew\nnew\n", op: fdiff.Add},
+			},
+			want: []LineRange{{Start: 3, End: 3}, {Start: 5, End: 7}},
+		},
+		{
+			name: "deleted lines only",
+			chunks: []fdiff.Chunk{
+				chunk{content: "a\n", op: fdiff.Equal},
+				chunk{content: "b\n", op: fdiff.Delete},
+			},
+			want: []LineRange{},
+		},
+		{
+			name: "missing newline at end of file",
+			chunks: []fdiff.Chunk{
+				chunk{content: "a\n", op: fdiff.Equal},
+				chunk{content: "b\nc", op: fdiff.Add},
+			},
+			want: []LineRange{{Start: 2, End: 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := changedRanges(test.chunks)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("changedRanges() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestChangedLinesFilter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	changedFile := filepath.Join(dir, "pkg", "changed.go")
+	unchangedFile := filepath.Join(dir, "other", "unchanged.go")
+
+	for _, path := range []string{changedFile, unchangedFile} {
+		err := os.MkdirAll(filepath.Dir(path), 0o750)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte("package main\n"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changedLines := ChangedLines{filepath.ToSlash(changedFile): {{Start: 10, End: 12}}}
+
+	finding := func(message string, path string, startLine int, endLine int) ci.Finding {
+		return ci.Finding{ToolName: "tool", RuleID: "rule", Level: "error", FilePath: path, StartLine: startLine, EndLine: endLine, Message: message}
+	}
+
+	findings := []ci.Finding{
+		finding("on changed line", changedFile, 11, 11),
+		finding("spanning changed lines", changedFile, 8, 10),
+		finding("before changed lines", changedFile, 9, 9),
+		finding("after changed lines", changedFile, 13, 0),
+		finding("file-level in changed file", changedFile, 0, 0),
+		finding("in unchanged file", unchangedFile, 1, 1),
+		finding("package of changed file", filepath.Join(dir, "pkg"), 0, 0),
+		finding("package of unchanged file", filepath.Join(dir, "other"), 0, 0),
+		finding("repository-level", "PR title", 0, 0),
+	}
+
+	kept, dropped := changedLines.Filter(findings)
+
+	messages := func(findings []ci.Finding) []string {
+		messages := []string{}
+		for _, finding := range findings {
+			messages = append(messages, finding.Message)
+		}
+
+		return messages
+	}
+
+	wantKept := []string{"on changed line", "spanning changed lines", "file-level in changed file", "package of changed file", "repository-level"}
+	if got := messages(kept); !reflect.DeepEqual(got, wantKept) {
+		t.Errorf("kept findings = %v, want %v", got, wantKept)
+	}
+
+	wantDropped := []string{"before changed lines", "after changed lines", "in unchanged file", "package of unchanged file"}
+	if got := messages(dropped); !reflect.DeepEqual(got, wantDropped) {
+		t.Errorf("dropped findings = %v, want %v", got, wantDropped)
+	}
+}
@@ -5,14 +5,20 @@
 package dispatch
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kemadev/ci-cd/internal/branch"
 	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/internal/fix"
 	"github.com/kemadev/ci-cd/internal/lint"
 	"github.com/kemadev/ci-cd/internal/pr"
 	"github.com/kemadev/ci-cd/pkg/ci"
@@ -21,11 +27,14 @@ import (
 )
 
 var (
-	ErrUnknownCommand    = fmt.Errorf("unknown command")
-	ErrNoCommandProvided = fmt.Errorf("no command provided")
-	ErrExitCodeNotZero   = fmt.Errorf("exit code is not zero")
-	ErrFindingFound      = fmt.Errorf("finding found")
-	ErrCommandFailed     = fmt.Errorf("command failed")
+	ErrUnknownCommand     = fmt.Errorf("unknown command")
+	ErrNoCommandProvided  = fmt.Errorf("no command provided")
+	ErrExitCodeNotZero    = fmt.Errorf("exit code is not zero")
+	ErrFindingFound       = fmt.Errorf("finding found")
+	ErrCommandFailed      = fmt.Errorf("command failed")
+	ErrMissingArgument    = fmt.Errorf("missing argument")
+	ErrCommandConflict    = fmt.Errorf("linter definition conflicts with a built-in command")
+	ErrFixDiffUnsupported = fmt.Errorf("suggested fixes can not be written as a diff")
 )
 
 const (
@@ -47,11 +56,318 @@ const (
 	CommandBranchStaleCheck = "branch-stale-check"
 	CommandCI               = "ci"
 	CommandDepsBump         = "deps-bump"
+	CommandMappingTest      = "mapping-test"
+	CommandDoctor           = "doctor"
 	CommandHelp             = "help"
 )
 
+// Commands running linters, which are run by CommandCI.
+func linterCommands() []string {
+	return []string{
+		CommandDocker,
+		CommandGHA,
+		CommandSecrets,
+		CommandSAST,
+		CommandGoTest,
+		CommandGoCover,
+		CommandGoBuild,
+		CommandGoModTidy,
+		CommandGoModName,
+		CommandGoLint,
+		CommandDeps,
+		CommandMarkdown,
+		CommandShell,
+	}
+}
+
+// All built-in commands, which linter definitions cannot override.
+func builtinCommands() []string {
+	return append(
+		linterCommands(),
+		CommandRelease,
+		CommandPRTitleCheck,
+		CommandBranchStaleCheck,
+		CommandCI,
+		CommandDepsBump,
+		CommandMappingTest,
+		CommandDoctor,
+		CommandHelp,
+	)
+}
+
+// Built-in commands using linter definitions.
+func definitionsCommands() []string {
+	return []string{
+		CommandCI,
+		CommandMappingTest,
+		CommandDoctor,
+		CommandHelp,
+	}
+}
+
+// Load linter definitions from config directories, each of them being a command run by CommandCI. Invalid definitions
+// are returned apart with their error by command name.
+func linterDefinitions() ([]lint.LinterDefinition, map[string]error, error) {
+	dir, err := config.SelectFile(config.LinterDefinitionsDirPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error selecting linter definitions directory: %w", err)
+	}
+
+	definitions, invalid, err := lint.ReadLinterDefinitions(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading linter definitions: %w", err)
+	}
+
+	definitions = slices.DeleteFunc(definitions, func(definition lint.LinterDefinition) bool {
+		if !slices.Contains(builtinCommands(), definition.Name) {
+			return false
+		}
+
+		invalid[definition.Name] = fmt.Errorf("%s: %w", definition.Name, ErrCommandConflict)
+
+		return true
+	})
+
+	return definitions, invalid, nil
+}
+
+// Log invalid linter definitions, for commands that do not run them to still point them out.
+func warnInvalidDefinitions(invalid map[string]error) {
+	for _, name := range slices.Sorted(maps.Keys(invalid)) {
+		slog.Warn("ignoring invalid linter definition", slog.String("name", name), slog.String("error", invalid[name].Error()))
+	}
+}
+
+// Parse fix flags of linter commands, which are to be passed after the command name.
+func parseFixFlags(conf *config.Config, args []string) error {
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fixEnabled := flags.Bool("fix", false, "apply suggested fixes of reported findings")
+	fixDiff := flags.String("fix-diff", "", "write suggested fixes of reported findings to given path as a unified diff")
+
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return fmt.Errorf("error parsing flags: %w", err)
+	}
+
+	// Only set when passed, as commands run by CommandCI share configuration
+	if *fixEnabled {
+		conf.Fix = true
+	}
+
+	if *fixDiff != "" {
+		conf.FixDiffPath = *fixDiff
+	}
+
+	return nil
+}
+
+// Apply suggested fixes of reported findings, or write them as a unified diff, as requested using fix flags.
+func ApplyFixes(conf *config.Config) error {
+	if !conf.Fix && conf.FixDiffPath == "" {
+		return nil
+	}
+
+	fixes, err := fix.Compute(conf.Findings.Findings())
+	if err != nil {
+		return fmt.Errorf("error computing fixes: %w", err)
+	}
+
+	for _, fileFix := range fixes {
+		slog.Info(
+			"fixes computed",
+			slog.String("file", fileFix.Path),
+			slog.Int("applied", fileFix.Applied),
+			slog.Int("skipped", fileFix.Skipped),
+		)
+	}
+
+	if conf.FixDiffPath != "" {
+		file, err := os.Create(conf.FixDiffPath)
+		if err != nil {
+			return fmt.Errorf("error creating fix diff file: %w", err)
+		}
+
+		err = fix.WriteDiff(file, fixes)
+		if err != nil {
+			file.Close()
+
+			return fmt.Errorf("error writing fix diff: %w", err)
+		}
+
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("error closing fix diff file: %w", err)
+		}
+	}
+
+	if conf.Fix {
+		err := fix.Apply(fixes)
+		if err != nil {
+			return fmt.Errorf("error applying fixes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Findings of all commands that ran, merging the ones reported by several tools.
+func deduplicatedFindings(conf *config.Config) ([]ci.Finding, error) {
+	var equivalences *ci.RuleEquivalences
+
+	if conf.RuleEquivalences != nil {
+		var err error
+
+		equivalences, err = conf.RuleEquivalences()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ci.DeduplicateFindings(conf.Findings.Findings(), equivalences), nil
+}
+
+// Print findings of all commands that ran, merging the ones reported by several tools, along with test suites of go
+// test runs.
+func printCollectedFindings(conf *config.Config) error {
+	findings, err := deduplicatedFindings(conf)
+	if err != nil {
+		return err
+	}
+
+	err = ci.PrintFindings(
+		findings,
+		lint.GetOutputFormat(conf),
+		conf.Findings.GoTestJunits(),
+	)
+	if err != nil {
+		return fmt.Errorf("error printing findings: %w", err)
+	}
+
+	return nil
+}
+
+// Append a Markdown summary of findings to the GitHub Actions job summary, see
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-commands#adding-a-job-summary
+func writeStepSummary(conf *config.Config) error {
+	summaryPath := os.Getenv("GITHUB_STEP_SUMMARY")
+	if os.Getenv("GITHUB_ACTIONS") == "" || summaryPath == "" {
+		return nil
+	}
+
+	slog.Debug("writing job summary", slog.String("path", summaryPath))
+
+	findings, err := deduplicatedFindings(conf)
+	if err != nil {
+		return err
+	}
+
+	//nolint:mnd // usual file permissions
+	file, err := os.OpenFile(summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening job summary file: %w", err)
+	}
+
+	err = ci.WriteFindings(file, findings, "markdown", nil)
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("error writing job summary: %w", err)
+	}
+
+	ci.WriteSuppressedFindingsMarkdown(file, conf.Findings.Filtered(lint.FilteredSuppressed))
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("error closing job summary file: %w", err)
+	}
+
+	return nil
+}
+
+// Write report files requested using global flags, as well as job summary, combining findings of all commands that ran.
+func WriteReports(conf *config.Config) error {
+	err := writeStepSummary(conf)
+	if err != nil {
+		return err
+	}
+
+	var findings []ci.Finding
+
+	if len(conf.Reports) > 0 {
+		findings, err = deduplicatedFindings(conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, report := range conf.Reports {
+		slog.Debug("writing report", slog.String("format", report.Format), slog.String("path", report.Path))
+
+		file, err := os.Create(report.Path)
+		if err != nil {
+			return fmt.Errorf("error creating report file %s: %w", report.Path, err)
+		}
+
+		err = ci.WriteFindings(
+			file,
+			findings,
+			report.Format,
+			conf.Findings.GoTestJunits(),
+		)
+		if err != nil {
+			file.Close()
+
+			return fmt.Errorf("error writing report file %s: %w", report.Path, err)
+		}
+
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("error closing report file %s: %w", report.Path, err)
+		}
+	}
+
+	if conf.BaselineWritePath != "" {
+		err := writeBaseline(conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write a baseline of all findings, including those that were not reported because they are suppressed, already part
+// of the baseline in use or outside of changed lines.
+func writeBaseline(conf *config.Config) error {
+	slog.Debug("writing baseline", slog.String("path", conf.BaselineWritePath))
+
+	file, err := os.Create(conf.BaselineWritePath)
+	if err != nil {
+		return fmt.Errorf("error creating baseline file: %w", err)
+	}
+
+	findings := conf.Findings.Findings()
+	for _, reason := range []string{lint.FilteredSuppressed, lint.FilteredBaseline, lint.FilteredDiff} {
+		findings = append(findings, conf.Findings.Filtered(reason)...)
+	}
+
+	err = ci.WriteBaseline(file, findings)
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("error writing baseline: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("error closing baseline file: %w", err)
+	}
+
+	return nil
+}
+
 //nolint:funlen // the enormous switch is (hopefully) easily understandable for a human
-func Run(conf *config.Config, args []string) (int, error) {
+func Run(ctx context.Context, conf *config.Config, args []string) (int, error) {
 	gitSvc := git.NewGitService()
 
 	gitRepoBasePath, err := gitSvc.GetGitBasePath()
@@ -78,14 +394,39 @@ func Run(conf *config.Config, args []string) (int, error) {
 		return 1, fmt.Errorf("error getting files finding root path: %w", err)
 	}
 
+	var (
+		definitions        []lint.LinterDefinition
+		invalidDefinitions map[string]error
+	)
+
+	// Definitions are only loaded by commands using them, so that other ones do not depend on them being valid
+	if !slices.Contains(builtinCommands(), args[0]) || slices.Contains(definitionsCommands(), args[0]) {
+		definitions, invalidDefinitions, err = linterDefinitions()
+		if err != nil {
+			return 1, err
+		}
+	}
+
+	definitionIndex := slices.IndexFunc(definitions, func(definition lint.LinterDefinition) bool {
+		return definition.Name == args[0]
+	})
+
 	goRc := 0
 	goErr := error(nil)
 
+	if slices.Contains(linterCommands(), args[0]) || args[0] == CommandCI || definitionIndex != -1 {
+		err := parseFixFlags(conf, args)
+		if err != nil {
+			return 1, fmt.Errorf("error parsing %s flags: %w", args[0], err)
+		}
+	}
+
 	switch args[0] {
 	case CommandDocker:
 		slog.Info("running " + CommandDocker)
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "hadolint",
@@ -97,28 +438,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"--format",
 					"json",
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "hadolint",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "code",
-						},
-						Level: ci.JSONMappingInfo{
-							Key: "level",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "file",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "line",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "message",
-						},
-					},
-				},
+				JSONInfo: hadolintJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandDocker+": %w", err)
@@ -130,6 +450,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 		slog.Info("running " + CommandGHA)
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "actionlint",
@@ -142,31 +463,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"-format",
 					"{{json .}}",
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "gha-actionlint",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "kind",
-						},
-						Level: ci.JSONMappingInfo{
-							OverrideValue: "warning",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "filepath",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "line",
-						},
-						StartCol: ci.JSONMappingInfo{
-							Key: "col",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "message",
-						},
-					},
-				},
+				JSONInfo: actionlintJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandGHA+": %w", err)
@@ -177,12 +474,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 	case CommandSecrets:
 		slog.Info("running " + CommandSecrets)
 
-		configFile, err := config.SelectFile("gitleaks/.gitleaksignore")
+		configFile, err := config.SelectFile(config.GitleaksIgnoreFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "gitleaks",
@@ -199,37 +497,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"--report-path",
 					"-",
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "secrets-gitleaks",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "RuleID",
-						},
-						Level: ci.JSONMappingInfo{
-							OverrideValue: "error",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "File",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "StartLine",
-						},
-						EndLine: ci.JSONMappingInfo{
-							Key: "EndLine",
-						},
-						StartCol: ci.JSONMappingInfo{
-							Key: "StartColumn",
-						},
-						EndCol: ci.JSONMappingInfo{
-							Key: "EndColumn",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "Description",
-						},
-					},
-				},
+				JSONInfo: gitleaksJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandSecrets+": %w", err)
@@ -241,6 +509,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 		slog.Info("running " + CommandSAST)
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "semgrep",
@@ -266,38 +535,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"--config",
 					"p/dockerfile",
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						BaseArrayKey: "results",
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "sast-semgrep",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "check_id",
-						},
-						Level: ci.JSONMappingInfo{
-							Key: "extra.severity",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "path",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "start.line",
-						},
-						EndLine: ci.JSONMappingInfo{
-							Key: "end.line",
-						},
-						StartCol: ci.JSONMappingInfo{
-							Key: "start.col",
-						},
-						EndCol: ci.JSONMappingInfo{
-							Key: "end.col",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "extra.message",
-						},
-					},
-				},
+				JSONInfo: semgrepJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandSAST+": %w", err)
@@ -308,6 +546,10 @@ func Run(conf *config.Config, args []string) (int, error) {
 	case CommandGoTest:
 		slog.Info("running " + CommandGoTest)
 
+		// Findings and test suites of all modules are printed together, as a single document, unless printing is
+		// already deferred to the end of all commands
+		printAtEnd := !conf.DeferPrinting
+
 		for _, mod := range goModList {
 			if strings.HasPrefix(mod, filesFindRootPath+"/deploy/") {
 				slog.Info("skipping "+CommandGoTest, slog.String("mod", mod))
@@ -317,6 +559,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 
 			slog.Info("running "+CommandGoTest, slog.String("mod", mod))
 			retCode, _, _, err := lint.RunLinter(
+				ctx,
 				conf,
 				lint.LinterArgs{
 					Workdir: strings.Split(mod, "go.mod")[0],
@@ -329,43 +572,9 @@ func Run(conf *config.Config, args []string) (int, error) {
 						"-race",
 						"./...",
 					},
-					JSONInfo: ci.JSONInfos{
-						Type: "stream",
-						Mappings: ci.JSONToFindingsMappings{
-							ToolName: ci.JSONMappingInfo{
-								OverrideValue: "go-test",
-							},
-							RuleID: ci.JSONMappingInfo{
-								OverrideValue: "no-failing-test",
-							},
-							Level: ci.JSONMappingInfo{
-								OverrideValue: "error",
-							},
-							FilePath: ci.JSONMappingInfo{
-								Key: "Package",
-								// Get path relative to git repo base path
-								ValueTransformerRegex: gitRepoBasePath + "/(.*)",
-								Suffix: &ci.JSONMappingInfo{
-									// Add a /
-									OverrideValue: "/",
-									Suffix: &ci.JSONMappingInfo{
-										Key: "Output",
-										// Name of test file producing the finding
-										ValueTransformerRegex: `\s*(\w+_test.go):`,
-									},
-								},
-							},
-							StartLine: ci.JSONMappingInfo{
-								Key:                   "Output",
-								ValueTransformerRegex: `\s*\w_test.go:(\d+):`,
-							},
-							Message: ci.JSONMappingInfo{
-								Key:                   "Output",
-								GlobalSelectorRegex:   `\s*(\w_test.go:\d+):`,
-								ValueTransformerRegex: `\s*\w_test.go:\d+:\s*(.*)`,
-							},
-						},
-					},
+					GoTestJSON:    true,
+					DeferPrinting: true,
+					JSONInfo:      goTestJSONInfo(gitRepoBasePath),
 				})
 
 			if retCode != 0 {
@@ -373,11 +582,21 @@ func Run(conf *config.Config, args []string) (int, error) {
 			}
 
 			if err != nil {
-				return 1, fmt.Errorf("error running go test in %s: %w", mod, err)
+				goRc = 1
+				goErr = fmt.Errorf("error running go test in %s: %w", mod, err)
+
+				break
 			}
 		}
 
-		return goRc, nil
+		if printAtEnd {
+			err := printCollectedFindings(conf)
+			if err != nil {
+				return 1, err
+			}
+		}
+
+		return goRc, goErr
 
 	case CommandGoCover:
 		slog.Info("running " + CommandGoCover)
@@ -391,6 +610,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 
 			slog.Info("running "+CommandGoCover, slog.String("mod", mod))
 			retCode, _, _, err := lint.RunLinter(
+				ctx,
 				conf,
 				lint.LinterArgs{
 					Workdir: strings.Split(mod, "go.mod")[0],
@@ -402,34 +622,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 						"./...",
 					},
 					FailOnAtLeastOneFinding: true,
-					JSONInfo: ci.JSONInfos{
-						Type: "stream",
-						Mappings: ci.JSONToFindingsMappings{
-							ToolName: ci.JSONMappingInfo{
-								OverrideValue: "go-cover",
-							},
-							RuleID: ci.JSONMappingInfo{
-								OverrideValue: "no-cover-below-70",
-							},
-							Level: ci.JSONMappingInfo{
-								OverrideValue: "error",
-							},
-							FilePath: ci.JSONMappingInfo{
-								Key: "Package",
-								// Get path relative to git repo base path
-								ValueTransformerRegex: gitRepoBasePath + "/(.*)",
-							},
-							Message: ci.JSONMappingInfo{
-								Key: "Output",
-								// Failed test or coverage lesser than 70%
-								GlobalSelectorRegex:   `coverage:\s*([0-6](\d)?(\.\d)?)\% of statements`,
-								ValueTransformerRegex: `coverage:\s*([0-6](\d)?(\.\d)?\%) of statements`,
-								Suffix: &ci.JSONMappingInfo{
-									OverrideValue: " package coverage is below 70%",
-								},
-							},
-						},
-					},
+					JSONInfo:                goCoverJSONInfo(gitRepoBasePath),
 				})
 
 			if retCode != 0 {
@@ -447,6 +640,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 		slog.Info("running " + CommandGoBuild)
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "goreleaser",
@@ -473,6 +667,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 		for _, mod := range goModList {
 			slog.Info("running "+CommandGoModTidy, slog.String("mod", mod))
 			retCode, _, _, err := lint.RunLinter(
+				ctx,
 				conf,
 				lint.LinterArgs{
 					Workdir: strings.Split(mod, "go.mod")[0],
@@ -506,6 +701,9 @@ func Run(conf *config.Config, args []string) (int, error) {
 							Message: ci.JSONMappingInfo{
 								OverrideValue: "Unused dependencies found in " + mod,
 							},
+							Category: ci.JSONMappingInfo{
+								OverrideValue: "dependency",
+							},
 						},
 					},
 				})
@@ -528,6 +726,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 			slog.Info("running "+CommandGoModName, slog.String("mod", mod))
 			expectedGoModName := gitRepoBasePath + strings.Split(strings.Join(strings.Split(mod, filesFindRootPath)[1:], ""), "/go.mod")[0]
 			retCode, _, _, err := lint.RunLinter(
+				ctx,
 				conf,
 				lint.LinterArgs{
 					Workdir: strings.Split(mod, "go.mod")[0],
@@ -573,6 +772,12 @@ func Run(conf *config.Config, args []string) (int, error) {
 									},
 								},
 							},
+							HelpURI: ci.JSONMappingInfo{
+								OverrideValue: "https://go.dev/ref/mod#module-path",
+							},
+							Category: ci.JSONMappingInfo{
+								OverrideValue: "lint",
+							},
 						},
 					},
 				})
@@ -589,14 +794,17 @@ func Run(conf *config.Config, args []string) (int, error) {
 		return goRc, goErr
 
 	case CommandGoLint:
-		var fixEnabled bool
-		if len(args) > 1 && args[1] == "--fix" {
-			fixEnabled = true
+		// golangci-lint fixes can not be computed from its output, as their positions are offsets of its own file set,
+		// rely on its own fix mode. They would silently be missing from a diff
+		if conf.FixDiffPath != "" {
+			return 1, fmt.Errorf(CommandGoLint+": golangci-lint fixes can only be applied using --fix: %w", ErrFixDiffUnsupported)
 		}
 
+		fixEnabled := conf.Fix
+
 		slog.Info("running "+CommandGoLint, slog.Bool("fixEnabled", fixEnabled))
 
-		configFile, err := config.SelectFile("golangci-lint/.golangci.yaml")
+		configFile, err := config.SelectFile(config.GolangciLintFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
@@ -614,36 +822,12 @@ func Run(conf *config.Config, args []string) (int, error) {
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
-				Bin:     "golangci-lint",
-				CliArgs: lintArgs,
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						BaseArrayKey: "Issues",
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "golangci-lint",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "FromLinter",
-						},
-						Level: ci.JSONMappingInfo{
-							Key: "Severity",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "Pos.Filename",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "Pos.Line",
-						},
-						StartCol: ci.JSONMappingInfo{
-							Key: "Pos.Column",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "Text",
-						},
-					},
-				},
+				Bin:      "golangci-lint",
+				CliArgs:  lintArgs,
+				JSONInfo: golangciLintJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandGoLint+": %w", err)
@@ -665,12 +849,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 			slog.String("outputFile", sbomFile.Name()),
 		)
 
-		configFileSyft, err := config.SelectFile("syft/.syft.yaml")
+		configFileSyft, err := config.SelectFile(config.SyftFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "syft",
@@ -686,6 +871,9 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"go",
 					".",
 				},
+				JSONInfo: ci.JSONInfos{
+					Type: "none",
+				},
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandDeps+": %w", err)
@@ -701,12 +889,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 			slog.String("outputFile", sbomFile.Name()),
 		)
 
-		configFileGrype, err := config.SelectFile("grype/.grype.yaml")
+		configFileGrype, err := config.SelectFile(config.GrypeFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
 
 		retCode, _, _, err = lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "grype",
@@ -717,51 +906,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 					"json",
 					sbomFile.Name(),
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						BaseArrayKey: "matches",
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "grype",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "vulnerability.id",
-						},
-						Level: ci.JSONMappingInfo{
-							Key:          "vulnerability.severity",
-							DefaultValue: "error",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "artifact.name",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "vulnerability.description",
-							Suffix: &ci.JSONMappingInfo{
-								OverrideValue: " - ",
-								Suffix: &ci.JSONMappingInfo{
-									Key: "vulnerability.dataSource",
-									Suffix: &ci.JSONMappingInfo{
-										OverrideValue: " - Found version: ",
-										Suffix: &ci.JSONMappingInfo{
-											Key: "artifact.version",
-											Suffix: &ci.JSONMappingInfo{
-												OverrideValue: " - Constraint: ",
-												Suffix: &ci.JSONMappingInfo{
-													Key: "matchDetails.found.versionConstraint",
-													Suffix: &ci.JSONMappingInfo{
-														OverrideValue: " - Suggested version: ",
-														Suffix: &ci.JSONMappingInfo{
-															Key: "matchDetails.fix.suggestedVersion",
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
+				JSONInfo: grypeJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandDeps+": %w", err)
@@ -772,12 +917,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 	case CommandMarkdown:
 		slog.Info("running " + CommandMarkdown)
 
-		configFile, err := config.SelectFile("markdownlint/.markdownlint.yaml")
+		configFile, err := config.SelectFile(config.MarkdownlintFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "markdownlint",
@@ -790,41 +936,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 				Paths: []string{
 					filesFindRootPath,
 				},
-				JSONInfo: ci.JSONInfos{
-					ReadFromStderr: true,
-					Mappings: ci.JSONToFindingsMappings{
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "markdownlint",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "ruleNames",
-						},
-						Level: ci.JSONMappingInfo{
-							OverrideValue: "error",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "fileName",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "lineNumber",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "ruleDescription",
-							Suffix: &ci.JSONMappingInfo{
-								OverrideValue: " - ",
-								Suffix: &ci.JSONMappingInfo{
-									Key: "errorDetail",
-									Suffix: &ci.JSONMappingInfo{
-										OverrideValue: " - ",
-										Suffix: &ci.JSONMappingInfo{
-											Key: "ruleInformation",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
+				JSONInfo: markdownlintJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandMarkdown+": %w", err)
@@ -836,6 +948,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 		slog.Info("running " + CommandShell)
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "shellcheck",
@@ -847,37 +960,7 @@ func Run(conf *config.Config, args []string) (int, error) {
 				Paths: []string{
 					filesFindRootPath,
 				},
-				JSONInfo: ci.JSONInfos{
-					Mappings: ci.JSONToFindingsMappings{
-						ToolName: ci.JSONMappingInfo{
-							OverrideValue: "shellcheck",
-						},
-						RuleID: ci.JSONMappingInfo{
-							Key: "code",
-						},
-						Level: ci.JSONMappingInfo{
-							Key: "level",
-						},
-						FilePath: ci.JSONMappingInfo{
-							Key: "file",
-						},
-						StartLine: ci.JSONMappingInfo{
-							Key: "line",
-						},
-						EndLine: ci.JSONMappingInfo{
-							Key: "endLine",
-						},
-						StartCol: ci.JSONMappingInfo{
-							Key: "column",
-						},
-						EndCol: ci.JSONMappingInfo{
-							Key: "endColumn",
-						},
-						Message: ci.JSONMappingInfo{
-							Key: "message",
-						},
-					},
-				},
+				JSONInfo: shellcheckJSONInfo(),
 			})
 		if err != nil {
 			return 1, fmt.Errorf(CommandShell+": %w", err)
@@ -902,12 +985,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 
 		slog.Info("running "+CommandRelease, slog.String("step", "goreleaser"))
 
-		configFile, err := config.SelectFile("goreleaser/.goreleaser.yaml")
+		configFile, err := config.SelectFile(config.GoreleaserFilePath)
 		if err != nil {
 			return 1, fmt.Errorf("error choosing config file: %w", err)
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin: "goreleaser",
@@ -917,6 +1001,9 @@ func Run(conf *config.Config, args []string) (int, error) {
 					configFile,
 					"--clean",
 				},
+				JSONInfo: ci.JSONInfos{
+					Type: "none",
+				},
 			})
 		if retCode != 0 {
 			return retCode, fmt.Errorf(
@@ -935,17 +1022,25 @@ func Run(conf *config.Config, args []string) (int, error) {
 	case CommandPRTitleCheck:
 		slog.Info("running " + CommandPRTitleCheck)
 
-		finding, err := pr.CheckPRTitle(os.Args[2])
+		if len(args) < 2 {
+			return 1, fmt.Errorf(CommandPRTitleCheck+": pr title: %w", ErrMissingArgument)
+		}
+
+		finding, err := pr.CheckPRTitle(args[1])
 		if err != nil {
 			return 1, fmt.Errorf("error checking PR title: %w", err)
 		}
 
-		if finding != (ci.Finding{}) {
-			err := ci.PrintFindings([]ci.Finding{finding}, lint.GetOutputFormat())
+		var reported []ci.Finding
+
+		if finding.ToolName != "" {
+			reported, err = lint.ReportFindings(conf, []ci.Finding{finding}, lint.GetOutputFormat(conf))
 			if err != nil {
-				return 1, fmt.Errorf("error printing findings: %w", err)
+				return 1, fmt.Errorf("error reporting findings: %w", err)
 			}
+		}
 
+		if lint.ShouldFail(conf, reported) {
 			return 1, fmt.Errorf("pr title check failed: %s: %w", finding.Message, ErrFindingFound)
 		}
 
@@ -961,12 +1056,16 @@ func Run(conf *config.Config, args []string) (int, error) {
 			return 1, fmt.Errorf("error checking stale branches: %w", err)
 		}
 
-		if finding != (ci.Finding{}) {
-			err := ci.PrintFindings([]ci.Finding{finding}, lint.GetOutputFormat())
+		var reported []ci.Finding
+
+		if finding.ToolName != "" {
+			reported, err = lint.ReportFindings(conf, []ci.Finding{finding}, lint.GetOutputFormat(conf))
 			if err != nil {
-				return 1, fmt.Errorf("error printing findings: %w", err)
+				return 1, fmt.Errorf("error reporting findings: %w", err)
 			}
+		}
 
+		if lint.ShouldFail(conf, reported) {
 			return 1, fmt.Errorf(
 				"stale branches check failed: %s: %w",
 				finding.Message,
@@ -983,68 +1082,86 @@ func Run(conf *config.Config, args []string) (int, error) {
 
 		var waitGroup sync.WaitGroup
 
-		commands := []string{
-			"docker",
-			"gha",
-			"secrets",
-			"sast",
-			"go-test",
-			"go-cover",
-			"go-build",
-			"go-mod-tidy",
-			"go-mod-name",
-			"go-lint",
-			"deps",
-			"markdown",
-			"shell",
+		commands := linterCommands()
+		for _, definition := range definitions {
+			commands = append(commands, definition.Name)
 		}
-		waitGroup.Add(len(commands))
+
+		// Findings of all commands are printed together, so that the ones reported by several tools can be merged. It is
+		// only set here, before commands start, as they read it concurrently
+		conf.DeferPrinting = true
 
 		var (
 			failedCommands   []string
 			failedCommandsMu sync.Mutex
 		)
 
+		runCommand := func(command string) {
+			retCode, err := Run(ctx, conf, []string{command})
+			if err != nil {
+				slog.Error(
+					"Error executing command",
+					slog.String("command", command),
+					slog.String("error", err.Error()),
+				)
+			}
+
+			if retCode != 0 {
+				slog.Error(
+					"Command failed",
+					slog.String("command", command),
+					slog.Int("returnCode", retCode),
+				)
+
+				failedCommandsMu.Lock()
+
+				failedCommands = append(failedCommands, command)
+
+				failedCommandsMu.Unlock()
+			} else {
+				slog.Debug("Command succeeded", slog.String("command", command))
+			}
+		}
+
+		// golangci-lint rewrites files in fix mode, other commands are to check files once it is done, for their fixes to
+		// refer to rewritten content
+		if conf.Fix && slices.Contains(commands, CommandGoLint) {
+			commands = slices.DeleteFunc(commands, func(command string) bool { return command == CommandGoLint })
+
+			slog.Info("running command", slog.String("command", CommandGoLint))
+			runCommand(CommandGoLint)
+		}
+
+		waitGroup.Add(len(commands))
+
 		for _, cmd := range commands {
 			slog.Info("running command", slog.String("command", cmd))
 
 			go func(command string) {
 				defer waitGroup.Done()
 
-				cmdArgs := []string{command}
-				if command == CommandGoLint && len(args) > 1 && args[1] == "--fix" {
-					cmdArgs = append(cmdArgs, "--fix")
-				}
-
-				retCode, err := Run(conf, cmdArgs)
-				if err != nil {
-					slog.Error(
-						"Error executing command",
-						slog.String("command", command),
-						slog.String("error", err.Error()),
-					)
-				}
-
-				if retCode != 0 {
-					slog.Error(
-						"Command failed",
-						slog.String("command", command),
-						slog.Int("returnCode", retCode),
-					)
-
-					failedCommandsMu.Lock()
-
-					failedCommands = append(failedCommands, command)
-
-					failedCommandsMu.Unlock()
-				} else {
-					slog.Debug("Command succeeded", slog.String("command", command))
-				}
+				runCommand(command)
 			}(cmd)
 		}
 
 		waitGroup.Wait()
 
+		// Invalid definitions only fail their own command
+		for _, name := range slices.Sorted(maps.Keys(invalidDefinitions)) {
+			slog.Error(
+				"Error executing command",
+				slog.String("command", name),
+				slog.String("error", invalidDefinitions[name].Error()),
+			)
+
+			failedCommands = append(failedCommands, name)
+		}
+
+		err := printCollectedFindings(conf)
+		if err != nil {
+			return 1, err
+		}
+
 		if len(failedCommands) > 0 {
 			return 1, fmt.Errorf(
 				"one or more commands failed: %s: %w",
@@ -1065,10 +1182,13 @@ func Run(conf *config.Config, args []string) (int, error) {
 		}
 
 		retCode, _, _, err := lint.RunLinter(
+			ctx,
 			conf,
 			lint.LinterArgs{
 				Bin:     "renovate",
 				CliArgs: []string{},
+				// Looking up updates of all dependencies of large repositories takes a while
+				Timeout: time.Hour,
 				JSONInfo: ci.JSONInfos{
 					Type: "none",
 				},
@@ -1079,7 +1199,21 @@ func Run(conf *config.Config, args []string) (int, error) {
 
 		return retCode, nil
 
+	case CommandMappingTest:
+		warnInvalidDefinitions(invalidDefinitions)
+
+		return runMappingTest(args[1:], definitions, gitRepoBasePath)
+
+	case CommandDoctor:
+		slog.Info("running " + CommandDoctor)
+
+		warnInvalidDefinitions(invalidDefinitions)
+
+		return runDoctor(ctx, conf, args[1:], definitions)
+
 	case "help":
+		warnInvalidDefinitions(invalidDefinitions)
+
 		slog.Info("Available commands:")
 		slog.Info("  " + CommandDocker + " - Run Dockerfile linter")
 		slog.Info("  " + CommandGHA + " - Run GitHub Actions linter")
@@ -1097,11 +1231,56 @@ func Run(conf *config.Config, args []string) (int, error) {
 		slog.Info("  " + CommandPRTitleCheck + " - Check PR title format")
 		slog.Info("  " + CommandBranchStaleCheck + " - Check for stale branches")
 		slog.Info("  " + CommandCI + " - Run all CI commands (mimics GitHub Pull Request CI)")
+		slog.Info("  " + CommandMappingTest + " - Print findings parsed from a captured tool output by mappings of a linter command or YAML definition file")
+		slog.Info("  " + CommandDoctor + " - Check tools are installed in their pinned version, and config files exist")
 		slog.Info("  " + CommandHelp + " - Show this help message")
 
+		if len(definitions) > 0 {
+			slog.Info("Declared linter commands (from " + config.LinterDefinitionsDirPath + " config directory):")
+
+			for _, definition := range definitions {
+				slog.Info("  " + definition.Name + " - " + definition.Description)
+			}
+		}
+
+		slog.Info("Global flags (to be passed before the command):")
+		slog.Info("  --format - Findings output format, one of human, json, github, gitlab, gitlab-codequality, sarif, junit, checkstyle, rdjson, markdown, html")
+		slog.Info("  --baseline - Only report findings that are not part of given baseline file")
+		slog.Info("  --baseline-write - Write a baseline of all findings to given file")
+		slog.Info("  --diff-base - Only report findings related to lines changed since merge base with given revision")
+		slog.Info("  --fail-on - Comma-separated findings levels making commands fail (e.g. warning,error) instead of tools exit code, also set by RUNNER_FAIL_ON")
+		slog.Info("  --timeout - Maximum duration of linter runs (e.g. 10m, default 30m, 0 for none), reported as a " + lint.TimeoutRuleID + " finding when reached, also set by RUNNER_TIMEOUT")
+		slog.Info("  --report - Write findings of all commands to a file as format=path (e.g. html=report.html), can be repeated")
+		slog.Info("Linter commands flags (to be passed after the command):")
+		slog.Info("  --fix - Apply suggested fixes of reported findings")
+		slog.Info("  --fix-diff - Write suggested fixes of reported findings to given file as a unified diff, instead of applying them (not supported by " + CommandGoLint + ")")
+		slog.Info(CommandMappingTest + " flags (to be passed after the command):")
+		slog.Info("  --golden - Compare findings to given golden file, printing a unified diff if they differ")
+		slog.Info("  --samples - Check captured outputs of given directory (<mapping>/<case>.output) against their golden files (<case>.golden.json)")
+		slog.Info("  --update - Write findings to golden files instead of comparing them")
+		slog.Info(CommandDoctor + " flags (to be passed after the command):")
+		slog.Info("  --generate-manifest - Generate pinned versions of tools (" + config.LocalConfigPath + config.ToolVersionsFilePath + ") from ARG pins of given Dockerfile")
+
 		return 0, nil
 
 	default:
-		return 1, fmt.Errorf("command %s: %w", args[0], ErrUnknownCommand)
+		if definitionErr, invalid := invalidDefinitions[args[0]]; invalid {
+			return 1, fmt.Errorf("invalid linter definition %s: %w", args[0], definitionErr)
+		}
+
+		if definitionIndex == -1 {
+			return 1, fmt.Errorf("command %s: %w", args[0], ErrUnknownCommand)
+		}
+
+		definition := definitions[definitionIndex]
+
+		slog.Info("running " + definition.Name)
+
+		retCode, _, _, err := lint.RunLinter(ctx, conf, definition.Linter)
+		if err != nil {
+			return 1, fmt.Errorf(definition.Name+": %w", err)
+		}
+
+		return retCode, nil
 	}
 }
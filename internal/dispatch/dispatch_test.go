@@ -0,0 +1,141 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/internal/lint"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+func TestWriteBaselineIncludesFilteredFindings(t *testing.T) {
+	t.Parallel()
+
+	finding := func(fingerprint string) ci.Finding {
+		return ci.Finding{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: "main.go", Message: "message", Fingerprint: fingerprint}
+	}
+
+	collector := ci.NewCollector()
+	collector.Add(finding("reported"))
+	collector.AddFiltered(lint.FilteredSuppressed, finding("suppressed"))
+	collector.AddFiltered(lint.FilteredBaseline, finding("baselined"))
+	collector.AddFiltered(lint.FilteredDiff, finding("unchanged"))
+
+	conf := &config.Config{Findings: collector, BaselineWritePath: filepath.Join(t.TempDir(), "baseline.json")}
+
+	err := writeBaseline(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := ci.ReadBaseline(conf.BaselineWritePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, entry := range baseline.Findings {
+		got = append(got, entry.Fingerprint)
+	}
+
+	want := []string{"reported", "suppressed", "baselined", "unchanged"}
+	if !slices.Equal(got, want) {
+		t.Errorf("baseline fingerprints = %v, want %v", got, want)
+	}
+}
+
+func TestDeduplicatedFindings(t *testing.T) {
+	t.Parallel()
+
+	collector := ci.NewCollector()
+	collector.Add(
+		ci.Finding{ToolName: "semgrep", RuleID: "sql", Level: "error", FilePath: "db.go", StartLine: 3, Message: "sql injection"},
+		ci.Finding{ToolName: "golangci-lint", RuleID: "gosec", Level: "error", FilePath: "db.go", StartLine: 3, Message: "G201", Tags: []string{"G201"}},
+	)
+
+	path := filepath.Join(t.TempDir(), ".equivalences.yaml")
+
+	err := os.WriteFile(path, []byte(`equivalences:
+  - id: CWE-89
+    rules:
+      - semgrep/sql
+      - golangci-lint/G201
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("merged findings", func(t *testing.T) {
+		t.Parallel()
+
+		conf := &config.Config{Findings: collector, RuleEquivalences: func() (*ci.RuleEquivalences, error) {
+			return ci.ReadRuleEquivalences(path)
+		}}
+
+		got, err := deduplicatedFindings(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 {
+			t.Errorf("deduplicatedFindings() = %+v, want a single merged finding", got)
+		}
+	})
+
+	t.Run("invalid equivalences file", func(t *testing.T) {
+		t.Parallel()
+
+		errInvalid := fmt.Errorf("invalid rule equivalences file")
+
+		conf := &config.Config{Findings: collector, RuleEquivalences: func() (*ci.RuleEquivalences, error) {
+			return nil, errInvalid
+		}}
+
+		_, err := deduplicatedFindings(conf)
+		if !errors.Is(err, errInvalid) {
+			t.Errorf("deduplicatedFindings() error = %v, want %v", err, errInvalid)
+		}
+	})
+}
+
+func TestWriteStepSummary(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	// Summary is appended to the one of previous steps
+	err := os.WriteFile(summaryPath, []byte("previous step\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+
+	collector := ci.NewCollector()
+	collector.Add(ci.Finding{ToolName: "semgrep", RuleID: "sql", Level: "error", FilePath: "db.go", Message: "sql injection"})
+	collector.AddFiltered(lint.FilteredSuppressed, ci.Finding{ToolName: "grype", RuleID: "CVE-2025-0001", Level: "error", FilePath: "go.mod", Message: "vulnerable"})
+
+	err = writeStepSummary(&config.Config{Findings: collector})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"previous step\n## Findings\n", "| semgrep | 1 | 0 | 0 | 0 | 1 |", "Suppressed findings (1)"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("summary does not contain %q:\n%s", want, content)
+		}
+	}
+}
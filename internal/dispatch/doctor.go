@@ -0,0 +1,124 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/internal/doctor"
+	"github.com/kemadev/ci-cd/internal/lint"
+)
+
+// Generate pinned versions of tools from ARG pins of given Dockerfile, writing them to local config directory.
+func generateManifest(dockerfile string) error {
+	file, err := os.Open(dockerfile)
+	if err != nil {
+		return fmt.Errorf("error opening dockerfile: %w", err)
+	}
+
+	defer file.Close()
+
+	manifest, err := doctor.GenerateManifest(file)
+	if err != nil {
+		return fmt.Errorf("error generating manifest: %w", err)
+	}
+
+	path := config.LocalConfigPath + config.ToolVersionsFilePath
+
+	//nolint:mnd // usual directory permissions
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("error creating manifest directory: %w", err)
+	}
+
+	output, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating manifest file: %w", err)
+	}
+
+	err = manifest.Write(output, dockerfile)
+	if err != nil {
+		output.Close()
+
+		return err
+	}
+
+	err = output.Close()
+	if err != nil {
+		return fmt.Errorf("error closing manifest file: %w", err)
+	}
+
+	slog.Info("manifest generated", slog.String("path", path), slog.Int("tools", len(manifest.Versions)))
+
+	return nil
+}
+
+// Check tools run by built-in and declared linter commands, and config files, reporting issues as findings.
+func runDoctor(ctx context.Context, conf *config.Config, args []string, definitions []lint.LinterDefinition) (int, error) {
+	flags := flag.NewFlagSet(CommandDoctor, flag.ContinueOnError)
+	dockerfile := flags.String("generate-manifest", "", "generate pinned versions of tools from ARG pins of given Dockerfile, e.g. build/Dockerfile")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return 1, fmt.Errorf("error parsing flags: %w", err)
+	}
+
+	if *dockerfile != "" {
+		err := generateManifest(*dockerfile)
+		if err != nil {
+			return 1, err
+		}
+
+		return 0, nil
+	}
+
+	manifestPath, err := config.SelectFile(config.ToolVersionsFilePath)
+	if err != nil {
+		return 1, fmt.Errorf("error selecting manifest file: %w", err)
+	}
+
+	// A missing manifest is reported along with other missing config files
+	manifest, err := doctor.ReadManifest(manifestPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 1, fmt.Errorf("error loading manifest: %w", err)
+	}
+
+	tools := doctor.Tools()
+
+	for _, definition := range definitions {
+		if !slices.ContainsFunc(tools, func(tool doctor.Tool) bool { return tool.Bin == definition.Linter.Bin }) {
+			tools = append(tools, doctor.Tool{Bin: definition.Linter.Bin, VersionArgs: nil})
+		}
+	}
+
+	findings := doctor.CheckTools(ctx, tools, manifest)
+
+	configFindings, err := doctor.CheckConfigFiles(config.RequiredFiles(), config.OptionalFiles())
+	if err != nil {
+		return 1, fmt.Errorf("error checking config files: %w", err)
+	}
+
+	findings = append(findings, configFindings...)
+
+	reported, err := lint.ReportFindings(conf, findings, lint.GetOutputFormat(conf))
+	if err != nil {
+		return 1, fmt.Errorf("error reporting findings: %w", err)
+	}
+
+	if lint.ShouldFail(conf, reported) {
+		return 1, fmt.Errorf("environment check failed: %w", ErrFindingFound)
+	}
+
+	slog.Info("environment check passed")
+
+	return 0, nil
+}
@@ -0,0 +1,456 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import "github.com/kemadev/ci-cd/pkg/ci"
+
+// Mappings of hadolint JSON output.
+func hadolintJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "hadolint",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "code",
+			},
+			Level: ci.JSONMappingInfo{
+				Key: "level",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "file",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "line",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "message",
+			},
+			HelpURI: ci.JSONMappingInfo{
+				OverrideValue: "https://github.com/hadolint/hadolint/wiki/",
+				Suffix: &ci.JSONMappingInfo{
+					Key: "code",
+				},
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "lint",
+			},
+		},
+	}
+}
+
+// Mappings of actionlint JSON output.
+func actionlintJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "gha-actionlint",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "kind",
+			},
+			Level: ci.JSONMappingInfo{
+				OverrideValue: "warning",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "filepath",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "line",
+			},
+			StartCol: ci.JSONMappingInfo{
+				Key: "column",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "message",
+			},
+			HelpURI: ci.JSONMappingInfo{
+				OverrideValue: "https://github.com/rhysd/actionlint/blob/main/docs/checks.md",
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "lint",
+			},
+		},
+	}
+}
+
+// Mappings of gitleaks JSON report.
+func gitleaksJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "secrets-gitleaks",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "RuleID",
+			},
+			Level: ci.JSONMappingInfo{
+				OverrideValue: "error",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "File",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "StartLine",
+			},
+			EndLine: ci.JSONMappingInfo{
+				Key: "EndLine",
+			},
+			StartCol: ci.JSONMappingInfo{
+				Key: "StartColumn",
+			},
+			EndCol: ci.JSONMappingInfo{
+				Key: "EndColumn",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "Description",
+			},
+			Tags: ci.JSONMappingInfo{
+				Key: "Tags",
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "secret",
+			},
+		},
+	}
+}
+
+// Mappings of semgrep JSON output.
+func semgrepJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			BaseArrayKey: "results",
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "sast-semgrep",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "check_id",
+			},
+			Level: ci.JSONMappingInfo{
+				Key: "extra.severity",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "path",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "start.line",
+			},
+			EndLine: ci.JSONMappingInfo{
+				Key: "end.line",
+			},
+			StartCol: ci.JSONMappingInfo{
+				Key: "start.col",
+			},
+			EndCol: ci.JSONMappingInfo{
+				Key: "end.col",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "extra.message",
+			},
+			HelpURI: ci.JSONMappingInfo{
+				Key: "extra.metadata.source",
+			},
+			Tags: ci.JSONMappingInfo{
+				Key: "extra.metadata.cwe",
+				// Keep CWE ID only, as in `CWE-89: Improper Neutralization...`
+				ValueTransformerRegex: `^(CWE-\d+)`,
+			},
+			Category: ci.JSONMappingInfo{
+				Key:          "extra.metadata.category",
+				DefaultValue: "security",
+			},
+			Fix: ci.JSONFixMappings{
+				Description: ci.JSONMappingInfo{
+					OverrideValue: "Semgrep autofix",
+				},
+				// Replaces the whole finding range
+				Text: ci.JSONMappingInfo{
+					Key: "extra.fix",
+				},
+			},
+		},
+	}
+}
+
+// Mappings of `go test -json` output, reporting failing tests.
+func goTestJSONInfo(gitRepoBasePath string) ci.JSONInfos {
+	return ci.JSONInfos{
+		Type: "stream",
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "go-test",
+			},
+			RuleID: ci.JSONMappingInfo{
+				OverrideValue: "no-failing-test",
+			},
+			Level: ci.JSONMappingInfo{
+				OverrideValue: "error",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "Package",
+				// Get path relative to git repo base path
+				ValueTransformerRegex: gitRepoBasePath + "/(.*)",
+				Suffix: &ci.JSONMappingInfo{
+					// Add a /
+					OverrideValue: "/",
+					Suffix: &ci.JSONMappingInfo{
+						Key: "Output",
+						// Name of test file producing the finding
+						ValueTransformerRegex: `\s*(\w+_test.go):`,
+					},
+				},
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key:                   "Output",
+				ValueTransformerRegex: `\s*\w_test.go:(\d+):`,
+			},
+			Message: ci.JSONMappingInfo{
+				Key:                   "Output",
+				GlobalSelectorRegex:   `\s*(\w_test.go:\d+):`,
+				ValueTransformerRegex: `\s*\w_test.go:\d+:\s*(.*)`,
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "test",
+			},
+		},
+	}
+}
+
+// Mappings of `go test -json` output, reporting packages which coverage is below 70%.
+func goCoverJSONInfo(gitRepoBasePath string) ci.JSONInfos {
+	return ci.JSONInfos{
+		Type: "stream",
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "go-cover",
+			},
+			RuleID: ci.JSONMappingInfo{
+				OverrideValue: "no-cover-below-70",
+			},
+			Level: ci.JSONMappingInfo{
+				OverrideValue: "error",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "Package",
+				// Get path relative to git repo base path
+				ValueTransformerRegex: gitRepoBasePath + "/(.*)",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "Output",
+				// Failed test or coverage lesser than 70%
+				GlobalSelectorRegex:   `coverage:\s*([0-6](\d)?(\.\d)?)\% of statements`,
+				ValueTransformerRegex: `coverage:\s*([0-6](\d)?(\.\d)?\%) of statements`,
+				Suffix: &ci.JSONMappingInfo{
+					OverrideValue: " package coverage is below 70%",
+				},
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "coverage",
+			},
+		},
+	}
+}
+
+// Mappings of golangci-lint JSON output.
+func golangciLintJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			BaseArrayKey: "Issues",
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "golangci-lint",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "FromLinter",
+			},
+			Level: ci.JSONMappingInfo{
+				Key: "Severity",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "Pos.Filename",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "Pos.Line",
+			},
+			StartCol: ci.JSONMappingInfo{
+				Key: "Pos.Column",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "Text",
+			},
+			HelpURI: ci.JSONMappingInfo{
+				OverrideValue: "https://golangci-lint.run/usage/linters/#",
+				Suffix: &ci.JSONMappingInfo{
+					Key: "FromLinter",
+				},
+			},
+			Tags: ci.JSONMappingInfo{
+				Key: "Text",
+				// Rule code of gosec issues, as in `G201: SQL string formatting`, other issues having no tag
+				ValueTransformerRegex: `^(?:(G\d+):)?`,
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "lint",
+			},
+		},
+	}
+}
+
+// Mappings of grype JSON output.
+func grypeJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			BaseArrayKey: "matches",
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "grype",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "vulnerability.id",
+			},
+			Level: ci.JSONMappingInfo{
+				Key:          "vulnerability.severity",
+				DefaultValue: "error",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "artifact.name",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "vulnerability.description",
+				Suffix: &ci.JSONMappingInfo{
+					OverrideValue: " - ",
+					Suffix: &ci.JSONMappingInfo{
+						Key: "vulnerability.dataSource",
+						Suffix: &ci.JSONMappingInfo{
+							OverrideValue: " - Found version: ",
+							Suffix: &ci.JSONMappingInfo{
+								Key: "artifact.version",
+								Suffix: &ci.JSONMappingInfo{
+									OverrideValue: " - Constraint: ",
+									Suffix: &ci.JSONMappingInfo{
+										Key: "matchDetails.found.versionConstraint",
+										Suffix: &ci.JSONMappingInfo{
+											OverrideValue: " - Suggested version: ",
+											Suffix: &ci.JSONMappingInfo{
+												Key: "matchDetails.fix.suggestedVersion",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			HelpURI: ci.JSONMappingInfo{
+				Key: "vulnerability.dataSource",
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "vulnerability",
+			},
+		},
+	}
+}
+
+// Mappings of markdownlint JSON output.
+func markdownlintJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		ReadFromStderr: true,
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "markdownlint",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "ruleNames",
+			},
+			Level: ci.JSONMappingInfo{
+				OverrideValue: "error",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "fileName",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "lineNumber",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "ruleDescription",
+				Suffix: &ci.JSONMappingInfo{
+					OverrideValue: " - ",
+					Suffix: &ci.JSONMappingInfo{
+						Key: "errorDetail",
+					},
+				},
+			},
+			HelpURI: ci.JSONMappingInfo{
+				Key: "ruleInformation",
+			},
+			Tags: ci.JSONMappingInfo{
+				Key: "ruleNames",
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "lint",
+			},
+		},
+	}
+}
+
+// Mappings of shellcheck JSON output.
+func shellcheckJSONInfo() ci.JSONInfos {
+	return ci.JSONInfos{
+		Mappings: ci.JSONToFindingsMappings{
+			ToolName: ci.JSONMappingInfo{
+				OverrideValue: "shellcheck",
+			},
+			RuleID: ci.JSONMappingInfo{
+				Key: "code",
+			},
+			Level: ci.JSONMappingInfo{
+				Key: "level",
+			},
+			FilePath: ci.JSONMappingInfo{
+				Key: "file",
+			},
+			StartLine: ci.JSONMappingInfo{
+				Key: "line",
+			},
+			EndLine: ci.JSONMappingInfo{
+				Key: "endLine",
+			},
+			StartCol: ci.JSONMappingInfo{
+				Key: "column",
+			},
+			EndCol: ci.JSONMappingInfo{
+				Key: "endColumn",
+			},
+			Message: ci.JSONMappingInfo{
+				Key: "message",
+			},
+			HelpURI: ci.JSONMappingInfo{
+				OverrideValue: "https://www.shellcheck.net/wiki/SC",
+				Suffix: &ci.JSONMappingInfo{
+					Key: "code",
+				},
+			},
+			Category: ci.JSONMappingInfo{
+				OverrideValue: "lint",
+			},
+			Fix: ci.JSONFixMappings{
+				BaseArrayKey: "fix.replacements",
+				StartLine: ci.JSONMappingInfo{
+					Key: "line",
+				},
+				EndLine: ci.JSONMappingInfo{
+					Key: "endLine",
+				},
+				StartCol: ci.JSONMappingInfo{
+					Key: "column",
+				},
+				EndCol: ci.JSONMappingInfo{
+					Key: "endColumn",
+				},
+				Text: ci.JSONMappingInfo{
+					Key: "replacement",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+// Parse findings of a captured output of mapping samples using given mappings.
+func sampleFindings(t *testing.T, jsonInfo ci.JSONInfos, path string) []ci.Finding {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := ci.FindingsFromJSON(string(content), jsonInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return findings
+}
+
+func TestDeduplicateSemgrepGosecFindings(t *testing.T) {
+	t.Parallel()
+
+	equivalences, err := ci.ReadRuleEquivalences("../../config/dedup/.equivalences.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := append(
+		sampleFindings(t, semgrepJSONInfo(), "../../testdata/mappings/sast/sql-injection.output"),
+		sampleFindings(t, golangciLintJSONInfo(), "../../testdata/mappings/go-lint/gosec.output")...,
+	)
+
+	deduplicated := ci.DeduplicateFindings(findings, equivalences)
+
+	// SQL injection reported by both tools is merged, while errcheck issue is kept
+	if len(deduplicated) != 2 {
+		t.Fatalf("got %d findings, want 2: %v", len(deduplicated), deduplicated)
+	}
+
+	merged := deduplicated[0]
+	if merged.ToolName != "sast-semgrep" || !strings.HasSuffix(merged.Message, "(also reported by golangci-lint/gosec)") {
+		t.Errorf("merged finding = %+v, want semgrep finding also reported by golangci-lint/gosec", merged)
+	}
+
+	if deduplicated[1].RuleID != "errcheck" {
+		t.Errorf("kept finding rule = %s, want errcheck", deduplicated[1].RuleID)
+	}
+}
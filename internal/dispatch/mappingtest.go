@@ -0,0 +1,257 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kemadev/ci-cd/internal/fix"
+	"github.com/kemadev/ci-cd/internal/lint"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+var (
+	ErrUnknownMapping     = fmt.Errorf("unknown mapping")
+	ErrMappingNotTestable = fmt.Errorf("mapping does not parse findings from output")
+	ErrGoldenMismatch     = fmt.Errorf("findings do not match golden file")
+	ErrNoSample           = fmt.Errorf("no captured output found")
+)
+
+const (
+	// Extension of captured tool outputs in samples directories
+	sampleOutputExt = ".output"
+	// Extension of golden files in samples directories, next to the captured output of same name
+	sampleGoldenExt = ".golden.json"
+)
+
+// Mappings of built-in linter commands, by command name. Commands which mappings depend on the module being checked
+// are not part of them.
+func builtinMappings(gitRepoBasePath string) map[string]ci.JSONInfos {
+	return map[string]ci.JSONInfos{
+		CommandDocker:   hadolintJSONInfo(),
+		CommandGHA:      actionlintJSONInfo(),
+		CommandSecrets:  gitleaksJSONInfo(),
+		CommandSAST:     semgrepJSONInfo(),
+		CommandGoTest:   goTestJSONInfo(gitRepoBasePath),
+		CommandGoCover:  goCoverJSONInfo(gitRepoBasePath),
+		CommandGoLint:   golangciLintJSONInfo(),
+		CommandDeps:     grypeJSONInfo(),
+		CommandMarkdown: markdownlintJSONInfo(),
+		CommandShell:    shellcheckJSONInfo(),
+	}
+}
+
+// Find mappings by name of a built-in or declared linter command, or by path of a YAML linter definition file.
+func findMapping(name string, definitions []lint.LinterDefinition, gitRepoBasePath string) (ci.JSONInfos, error) {
+	if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" {
+		definition, err := lint.ReadLinterDefinition(name)
+		if err != nil {
+			return ci.JSONInfos{}, err
+		}
+
+		return definition.Linter.JSONInfo, nil
+	}
+
+	index := slices.IndexFunc(definitions, func(definition lint.LinterDefinition) bool {
+		return definition.Name == name
+	})
+	if index != -1 {
+		return definitions[index].Linter.JSONInfo, nil
+	}
+
+	jsonInfo, ok := builtinMappings(gitRepoBasePath)[name]
+	if !ok {
+		return ci.JSONInfos{}, fmt.Errorf("%s: %w", name, ErrUnknownMapping)
+	}
+
+	return jsonInfo, nil
+}
+
+// Parse findings of a captured tool output, the same way they are parsed while linters run, as an indented JSON
+// document.
+func mappingFindings(jsonInfo ci.JSONInfos, outputPath string) ([]byte, error) {
+	if jsonInfo.IsUnset() || jsonInfo.Type == "none" || jsonInfo.Type == "plain" {
+		return nil, fmt.Errorf("type %s: %w", jsonInfo.Type, ErrMappingNotTestable)
+	}
+
+	plan, err := ci.CompileJSONInfos(jsonInfo)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling mappings: %w", err)
+	}
+
+	var r io.Reader = os.Stdin
+
+	if outputPath != "-" {
+		file, err := os.Open(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("error opening output file: %w", err)
+		}
+
+		defer file.Close()
+
+		r = file
+	}
+
+	// Empty array rather than null when there is no finding
+	findings := []ci.Finding{}
+
+	err = plan.Decode(r, func(finding ci.Finding) {
+		findings = append(findings, finding)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing findings: %w", err)
+	}
+
+	content, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling findings to JSON: %w", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// Compare findings to golden file, printing a unified diff if they differ, or write them to golden file if update is
+// requested.
+func checkGolden(findings []byte, goldenPath string, update bool) error {
+	if update {
+		//nolint:mnd // usual file permissions
+		err := os.WriteFile(goldenPath, findings, 0o644)
+		if err != nil {
+			return fmt.Errorf("error writing golden file: %w", err)
+		}
+
+		slog.Info("golden file updated", slog.String("path", goldenPath))
+
+		return nil
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("error reading golden file, use --update to create it: %w", err)
+	}
+
+	if bytes.Equal(golden, findings) {
+		return nil
+	}
+
+	err = fix.WriteDiff(os.Stdout, []fix.FileFix{
+		{
+			Path:     goldenPath,
+			Original: string(golden),
+			Fixed:    string(findings),
+			Applied:  0,
+			Skipped:  0,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%s: %w", goldenPath, ErrGoldenMismatch)
+}
+
+// Check captured outputs of a samples directory, which holds a directory per mapping name, containing captured outputs
+// (`<case>.output`) and their golden files (`<case>.golden.json`).
+func checkSamples(dir string, definitions []lint.LinterDefinition, gitRepoBasePath string, update bool) (int, error) {
+	outputs, err := filepath.Glob(filepath.Join(dir, "*", "*"+sampleOutputExt))
+	if err != nil {
+		return 1, fmt.Errorf("error listing samples: %w", err)
+	}
+
+	if len(outputs) == 0 {
+		return 1, fmt.Errorf("%s: %w", dir, ErrNoSample)
+	}
+
+	var failed []string
+
+	for _, outputPath := range outputs {
+		name := filepath.Base(filepath.Dir(outputPath))
+		goldenPath := strings.TrimSuffix(outputPath, sampleOutputExt) + sampleGoldenExt
+
+		jsonInfo, err := findMapping(name, definitions, gitRepoBasePath)
+		if err == nil {
+			var findings []byte
+
+			findings, err = mappingFindings(jsonInfo, outputPath)
+			if err == nil {
+				err = checkGolden(findings, goldenPath, update)
+			}
+		}
+
+		if err != nil {
+			slog.Error("mapping test failed", slog.String("output", outputPath), slog.String("error", err.Error()))
+
+			failed = append(failed, outputPath)
+
+			continue
+		}
+
+		slog.Debug("mapping test passed", slog.String("output", outputPath))
+	}
+
+	if len(failed) > 0 {
+		return 1, fmt.Errorf("mapping tests failed: %s: %w", strings.Join(failed, ", "), ErrGoldenMismatch)
+	}
+
+	slog.Info("all mapping tests passed", slog.Int("count", len(outputs)))
+
+	return 0, nil
+}
+
+// Parse a captured tool output using given mappings, printing resulting findings or comparing them to a golden file.
+func runMappingTest(args []string, definitions []lint.LinterDefinition, gitRepoBasePath string) (int, error) {
+	flags := flag.NewFlagSet(CommandMappingTest, flag.ContinueOnError)
+	goldenPath := flags.String("golden", "", "compare findings to given golden file")
+	samplesDir := flags.String("samples", "", "check all captured outputs of given samples directory against their golden files")
+	update := flags.Bool("update", false, "write findings to golden files instead of comparing them")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return 1, fmt.Errorf("error parsing flags: %w", err)
+	}
+
+	if *samplesDir != "" {
+		return checkSamples(*samplesDir, definitions, gitRepoBasePath, *update)
+	}
+
+	//nolint:mnd // mapping and output file
+	if flags.NArg() != 2 {
+		return 1, fmt.Errorf("usage: %s [--golden path [--update]] <mapping> <output-file>: %w", CommandMappingTest, ErrMissingArgument)
+	}
+
+	jsonInfo, err := findMapping(flags.Arg(0), definitions, gitRepoBasePath)
+	if err != nil {
+		return 1, err
+	}
+
+	findings, err := mappingFindings(jsonInfo, flags.Arg(1))
+	if err != nil {
+		return 1, err
+	}
+
+	if *goldenPath == "" {
+		_, err = os.Stdout.Write(findings)
+		if err != nil {
+			return 1, fmt.Errorf("error writing findings: %w", err)
+		}
+
+		return 0, nil
+	}
+
+	err = checkGolden(findings, *goldenPath, *update)
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package dispatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kemadev/ci-cd/internal/lint"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+func TestCheckSamplesOfBuiltinMappings(t *testing.T) {
+	t.Parallel()
+
+	code, err := checkSamples("../../testdata/mappings", nil, "", false)
+	if err != nil || code != 0 {
+		t.Errorf("checkSamples() = %d, %v, want captured outputs to match their golden files", code, err)
+	}
+}
+
+func TestCheckSamples(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mappingDir := filepath.Join(dir, "tool")
+
+	err := os.Mkdir(mappingDir, 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	definitions := []lint.LinterDefinition{
+		{
+			Name: "tool",
+			Linter: lint.LinterArgs{
+				JSONInfo: ci.JSONInfos{
+					Type: "",
+					Mappings: ci.JSONToFindingsMappings{
+						ToolName: ci.JSONMappingInfo{OverrideValue: "tool"},
+						RuleID:   ci.JSONMappingInfo{Key: "rule"},
+						Level:    ci.JSONMappingInfo{DefaultValue: "warning"},
+						FilePath: ci.JSONMappingInfo{Key: "file"},
+						Message:  ci.JSONMappingInfo{Key: "message"},
+					},
+				},
+			},
+		},
+	}
+
+	outputPath := filepath.Join(mappingDir, "case"+sampleOutputExt)
+	goldenPath := filepath.Join(mappingDir, "case"+sampleGoldenExt)
+
+	err = os.WriteFile(outputPath, []byte(`[{"rule":"a","file":"x.go","message":"m"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Golden file is missing until it is written using update
+	_, err = checkSamples(dir, definitions, "", false)
+	if !errors.Is(err, ErrGoldenMismatch) {
+		t.Errorf("checkSamples() without golden file error = %v, want %v", err, ErrGoldenMismatch)
+	}
+
+	code, err := checkSamples(dir, definitions, "", true)
+	if err != nil || code != 0 {
+		t.Fatalf("checkSamples() with update = %d, %v", code, err)
+	}
+
+	code, err = checkSamples(dir, definitions, "", false)
+	if err != nil || code != 0 {
+		t.Errorf("checkSamples() of updated golden file = %d, %v", code, err)
+	}
+
+	err = os.WriteFile(outputPath, []byte(`[{"rule":"b","file":"x.go","message":"m"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = checkSamples(dir, definitions, "", false)
+	if !errors.Is(err, ErrGoldenMismatch) {
+		t.Errorf("checkSamples() of changed output error = %v, want %v", err, ErrGoldenMismatch)
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[
+  {
+    "toolName": "tool",
+    "ruleID": "a",
+    "level": "warning",
+    "filePath": "x.go",
+    "startLine": 0,
+    "endLine": 0,
+    "startCol": 0,
+    "endCol": 0,
+    "message": "m"
+  }
+]
+`
+	if string(golden) != want {
+		t.Errorf("golden file =\n%s\nwant\n%s", golden, want)
+	}
+
+	_, err = checkSamples(t.TempDir(), definitions, "", false)
+	if !errors.Is(err, ErrNoSample) {
+		t.Errorf("checkSamples() of empty directory error = %v, want %v", err, ErrNoSample)
+	}
+}
+
+func TestFindMapping(t *testing.T) {
+	t.Parallel()
+
+	definitionPath := filepath.Join(t.TempDir(), "tool.yaml")
+
+	err := os.WriteFile(definitionPath, []byte(`bin: tool
+output:
+  type: regex
+  regex: '^(?P<file>[^:]+):(?P<line>\d+): (?P<message>.*)$'
+  mappings:
+    toolName:
+      overrideValue: tool
+    ruleId:
+      overrideValue: rule
+    level:
+      overrideValue: warning
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsonInfo, err := findMapping(definitionPath, nil, "")
+	if err != nil || jsonInfo.Type != "regex" {
+		t.Errorf("findMapping() of definition file = %+v, %v, want its mappings", jsonInfo, err)
+	}
+
+	jsonInfo, err = findMapping(CommandShell, nil, "")
+	if err != nil || jsonInfo.Mappings.ToolName.OverrideValue == "" {
+		t.Errorf("findMapping() of built-in command = %+v, %v, want its mappings", jsonInfo, err)
+	}
+
+	_, err = findMapping("unknown", nil, "")
+	if !errors.Is(err, ErrUnknownMapping) {
+		t.Errorf("findMapping() of unknown command error = %v, want %v", err, ErrUnknownMapping)
+	}
+}
+
+func TestMappingFindingsNotTestable(t *testing.T) {
+	t.Parallel()
+
+	for _, jsonInfo := range []ci.JSONInfos{{}, {Type: "none"}, {Type: "plain"}} {
+		_, err := mappingFindings(jsonInfo, "-")
+		if !errors.Is(err, ErrMappingNotTestable) {
+			t.Errorf("mappingFindings() of type %q error = %v, want %v", jsonInfo.Type, err, ErrMappingNotTestable)
+		}
+	}
+}
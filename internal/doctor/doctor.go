@@ -0,0 +1,296 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package doctor
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/pkg/ci"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNoVersion = fmt.Errorf("no version found")
+
+const (
+	ToolName                = "doctor"
+	RuleToolMissing         = "tool-missing"
+	RuleToolVersionMismatch = "tool-version-mismatch"
+	RuleConfigFileMissing   = "config-file-missing"
+	// Maximum duration of tools version commands, some of them being interpreted and slow to start
+	versionTimeout = 30 * time.Second
+)
+
+// Tool is an external binary commands rely on.
+type Tool struct {
+	Bin string
+	// Arguments making the binary print its version, which is not checked if empty
+	VersionArgs []string
+}
+
+// Tools run by built-in commands, which versions are pinned in build/Dockerfile as `ARG <BIN>_VERSION`.
+func Tools() []Tool {
+	return []Tool{
+		{Bin: "actionlint", VersionArgs: []string{"--version"}},
+		{Bin: "gitleaks", VersionArgs: []string{"version"}},
+		{Bin: "go", VersionArgs: []string{"version"}},
+		{Bin: "golangci-lint", VersionArgs: []string{"version"}},
+		{Bin: "goreleaser", VersionArgs: []string{"--version"}},
+		{Bin: "grype", VersionArgs: []string{"--version"}},
+		{Bin: "hadolint", VersionArgs: []string{"--version"}},
+		{Bin: "markdownlint", VersionArgs: []string{"--version"}},
+		{Bin: "renovate", VersionArgs: []string{"--version"}},
+		{Bin: "semgrep", VersionArgs: []string{"--version"}},
+		{Bin: "shellcheck", VersionArgs: []string{"--version"}},
+		{Bin: "syft", VersionArgs: []string{"--version"}},
+	}
+}
+
+// Manifest holds pinned versions of tools, by binary name.
+type Manifest struct {
+	Versions map[string]string `yaml:"versions"`
+}
+
+// Read manifest at given path.
+func ReadManifest(path string) (*Manifest, error) {
+	var manifest Manifest
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	err = yaml.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling file: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// Strip digest and `v` prefix of pinned or reported versions, as in `v2.2.2@sha256:...`.
+func normalizeVersion(version string) string {
+	version, _, _ = strings.Cut(version, "@")
+
+	return strings.TrimPrefix(version, "v")
+}
+
+// Generate manifest from ARG pins of a Dockerfile, such as `ARG GOLANGCI_LINT_VERSION=v2.2.2@sha256:...` pinning
+// golangci-lint version to 2.2.2.
+func GenerateManifest(r io.Reader) (*Manifest, error) {
+	exp, err := regexp.Compile(`^ARG\s+([A-Z0-9_]+)_VERSION=(\S+)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile regex: %w", err)
+	}
+
+	manifest := Manifest{Versions: map[string]string{}}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		match := exp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+
+		name := strings.ReplaceAll(strings.ToLower(match[1]), "_", "-")
+		manifest.Versions[name] = normalizeVersion(strings.Trim(match[2], `"`))
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error reading dockerfile: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// Write manifest as YAML, noting how it is generated.
+func (m *Manifest) Write(w io.Writer, source string) error {
+	_, err := fmt.Fprintf(w, "# Generated from ARG pins of %s using `doctor --generate-manifest`, do not edit\n", source)
+	if err != nil {
+		return fmt.Errorf("error writing manifest: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	//nolint:mnd // usual YAML indentation
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling manifest: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("error writing manifest: %w", err)
+	}
+
+	return nil
+}
+
+func finding(ruleID string, level string, filePath string, message string) ci.Finding {
+	return ci.Finding{
+		ToolName:    ToolName,
+		RuleID:      ruleID,
+		Level:       level,
+		FilePath:    filePath,
+		StartLine:   0,
+		EndLine:     0,
+		StartCol:    0,
+		EndCol:      0,
+		Message:     message,
+		Fingerprint: "",
+		HelpURI:     "",
+		Tags:        nil,
+		Category:    "environment",
+		Fix:         nil,
+	}
+}
+
+// Run version command of given tool, returning the first version number it prints.
+func toolVersion(ctx context.Context, tool Tool) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
+
+	//nolint:gosec // Tools are known ones or declared in linter definitions
+	output, err := exec.CommandContext(ctx, tool.Bin, tool.VersionArgs...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("error running version command: %w", err)
+	}
+
+	exp, err := regexp.Compile(`v?\d+\.\d+(\.\d+)?`)
+	if err != nil {
+		return "", fmt.Errorf("failed to compile regex: %w", err)
+	}
+
+	version := exp.FindString(string(output))
+	if version == "" {
+		return "", fmt.Errorf("output %q: %w", strings.TrimSpace(string(output)), ErrNoVersion)
+	}
+
+	return normalizeVersion(version), nil
+}
+
+// Check tools are found in PATH, and that their version matches the one pinned in manifest, if any.
+func CheckTools(ctx context.Context, tools []Tool, manifest *Manifest) []ci.Finding {
+	findings := []ci.Finding{}
+
+	for _, tool := range tools {
+		path, err := exec.LookPath(tool.Bin)
+		if err != nil {
+			findings = append(findings, finding(RuleToolMissing, "error", ToolName, tool.Bin+" is not found in PATH"))
+
+			continue
+		}
+
+		if len(tool.VersionArgs) == 0 {
+			slog.Info("tool found", slog.String("tool", tool.Bin), slog.String("path", path))
+
+			continue
+		}
+
+		version, err := toolVersion(ctx, tool)
+		if err != nil {
+			slog.Warn("could not determine tool version", slog.String("tool", tool.Bin), slog.String("error", err.Error()))
+
+			continue
+		}
+
+		slog.Info("tool found", slog.String("tool", tool.Bin), slog.String("path", path), slog.String("version", version))
+
+		if manifest == nil {
+			continue
+		}
+
+		pinned, ok := manifest.Versions[tool.Bin]
+		if !ok {
+			slog.Debug("tool version is not pinned", slog.String("tool", tool.Bin))
+
+			continue
+		}
+
+		if version != pinned {
+			findings = append(findings, finding(
+				RuleToolVersionMismatch,
+				"warning",
+				ToolName,
+				fmt.Sprintf("%s version is %s, while %s is pinned", tool.Bin, version, pinned),
+			))
+		}
+	}
+
+	return findings
+}
+
+// Resolve config file as config.SelectFile does, returning whether it exists.
+func configFileExists(file string) (string, bool, error) {
+	path, err := config.SelectFile(file)
+	if err != nil {
+		return "", false, fmt.Errorf("error selecting config file %s: %w", file, err)
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, false, nil
+	}
+
+	if err != nil {
+		return "", false, fmt.Errorf("error finding config file %s: %w", path, err)
+	}
+
+	return path, true, nil
+}
+
+// Check config files exist as resolved by config.SelectFile. Missing required files are findings, while missing optional
+// ones are only logged, as defaults apply.
+func CheckConfigFiles(required []string, optional []string) ([]ci.Finding, error) {
+	findings := []ci.Finding{}
+
+	for _, file := range required {
+		path, exists, err := configFileExists(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			findings = append(findings, finding(
+				RuleConfigFileMissing,
+				"error",
+				path,
+				fmt.Sprintf("config file %s is missing from both %s and %s", file, config.LocalConfigPath, config.DefaultConfigPath),
+			))
+
+			continue
+		}
+
+		slog.Info("config file found", slog.String("path", path))
+	}
+
+	for _, file := range optional {
+		path, exists, err := configFileExists(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			slog.Info("optional config file not found, defaults apply", slog.String("file", file))
+
+			continue
+		}
+
+		slog.Info("config file found", slog.String("path", path))
+	}
+
+	return findings, nil
+}
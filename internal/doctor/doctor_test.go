@@ -0,0 +1,176 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kemadev/ci-cd/internal/config"
+)
+
+func TestNormalizeVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"v2.2.2@sha256:abc": "2.2.2",
+		"1.7.7":             "1.7.7",
+		"v1.2":              "1.2",
+		"3.21@sha256:abc":   "3.21",
+	}
+
+	for version, want := range tests {
+		if got := normalizeVersion(version); got != want {
+			t.Errorf("normalizeVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestGenerateManifest(t *testing.T) {
+	t.Parallel()
+
+	dockerfile := `ARG GOLANGCI_LINT_VERSION=v2.2.2@sha256:abc
+  ARG HADOLINT_VERSION="v2.14.0"
+ARG BUILDKIT_SYNTAX=docker/dockerfile:1
+FROM golang:1.24
+RUN echo ARG NOT_A_VERSION=1.0.0
+`
+
+	manifest, err := GenerateManifest(strings.NewReader(dockerfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"golangci-lint": "2.2.2", "hadolint": "2.14.0"}
+	if !reflect.DeepEqual(manifest.Versions, want) {
+		t.Errorf("versions = %v, want %v", manifest.Versions, want)
+	}
+
+	path := filepath.Join(t.TempDir(), ".versions.yaml")
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = manifest.Write(file, "build/Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(read, manifest) {
+		t.Errorf("manifest read back = %+v, want %+v", read, manifest)
+	}
+}
+
+// Manifest is to be regenerated along with version pins of the Dockerfile.
+func TestManifestMatchesDockerfile(t *testing.T) {
+	t.Parallel()
+
+	dockerfile, err := os.Open("../../build/Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dockerfile.Close()
+
+	manifest, err := GenerateManifest(dockerfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want strings.Builder
+
+	err = manifest.Write(&want, "build/Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile("../../config/" + config.ToolVersionsFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != want.String() {
+		t.Errorf("manifest is outdated, run `doctor --generate-manifest build/Dockerfile`:\n%s\nwant\n%s", got, want.String())
+	}
+}
+
+func TestCheckTools(t *testing.T) {
+	dir := t.TempDir()
+
+	// Tool printing its version, as in `fake 1.2.3 (built ...)`
+	script := "#!/bin/sh\necho \"$(basename \"$0\") v1.2.3 (built today)\"\n"
+
+	for _, bin := range []string{"pinned-tool", "outdated-tool", "unpinned-tool"} {
+		//nolint:gosec // tools are to be executable
+		err := os.WriteFile(filepath.Join(dir, bin), []byte(script), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", dir)
+
+	manifest := &Manifest{Versions: map[string]string{"pinned-tool": "1.2.3", "outdated-tool": "1.3.0"}}
+
+	findings := CheckTools(context.Background(), []Tool{
+		{Bin: "pinned-tool", VersionArgs: []string{"--version"}},
+		{Bin: "outdated-tool", VersionArgs: []string{"--version"}},
+		{Bin: "unpinned-tool", VersionArgs: []string{"--version"}},
+		{Bin: "missing-tool", VersionArgs: []string{"--version"}},
+	}, manifest)
+
+	got := []string{}
+	for _, finding := range findings {
+		got = append(got, finding.RuleID+": "+finding.Message)
+	}
+
+	want := []string{
+		RuleToolVersionMismatch + ": outdated-tool version is 1.2.3, while 1.3.0 is pinned",
+		RuleToolMissing + ": missing-tool is not found in PATH",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %q, want %q", got, want)
+	}
+}
+
+func TestCheckConfigFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := os.MkdirAll(filepath.Join(config.LocalConfigPath, "tool"), 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(config.LocalConfigPath, "tool", "present.yaml"), []byte("{}\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := CheckConfigFiles(
+		[]string{"tool/present.yaml", "tool/missing.yaml"},
+		[]string{"tool/optional.yaml"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].RuleID != RuleConfigFileMissing || findings[0].FilePath != config.DefaultConfigPath+"tool/missing.yaml" {
+		t.Errorf("findings = %+v, want missing required file only", findings)
+	}
+}
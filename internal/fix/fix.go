@@ -0,0 +1,393 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package fix
+
+import (
+	"cmp"
+	// Registers SHA-1, used to compute git blob hashes of diffs
+	_ "crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v6/plumbing/format/diff"
+	gdiff "github.com/go-git/go-git/v6/utils/diff"
+	"github.com/kemadev/ci-cd/pkg/ci"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Number of unchanged lines around changes in unified diffs
+const diffContextLines = 3
+
+var ErrInvalidReplacement = fmt.Errorf("replacement is out of file bounds")
+
+// FileFix holds the content of a file before and after applying replacements of findings fixes.
+type FileFix struct {
+	Path     string
+	Original string
+	Fixed    string
+	// Count of replacements applied
+	Applied int
+	// Count of replacements skipped as they overlap an applied one, are out of file bounds, or refer to a previous
+	// content of the file
+	Skipped int
+}
+
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// Compute byte offsets of the start of each line.
+func lineOffsets(content string) []int {
+	offsets := []int{0}
+
+	for i, c := range content {
+		if c == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+
+	return offsets
+}
+
+// Byte offset of given 1-based line and column. A zero column means the start of the line, or its end (excluding line
+// break) if end is true.
+func offset(content string, offsets []int, line int, col int, end bool) (int, error) {
+	if line < 1 || line > len(offsets) {
+		return 0, fmt.Errorf("line %d: %w", line, ErrInvalidReplacement)
+	}
+
+	lineStart := offsets[line-1]
+
+	lineEnd := len(content)
+	if line < len(offsets) {
+		// Position of the line break, which can be replaced as well
+		lineEnd = offsets[line] - 1
+	}
+
+	if col <= 0 {
+		if end {
+			return lineEnd, nil
+		}
+
+		return lineStart, nil
+	}
+
+	position := lineStart + col - 1
+	if position > lineEnd+1 || position > len(content) {
+		return 0, fmt.Errorf("line %d column %d: %w", line, col, ErrInvalidReplacement)
+	}
+
+	return position, nil
+}
+
+func contentHash(content []byte) string {
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Record current content hash of files replacements of findings fixes refer to, for them to be skipped if files change
+// before fixes are applied, e.g. when they are rewritten by another tool. Replacements of unreadable files are left
+// as is.
+func RecordFileHashes(findings []ci.Finding) {
+	hashes := map[string]string{}
+
+	for _, finding := range findings {
+		if finding.Fix == nil {
+			continue
+		}
+
+		for i := range finding.Fix.Replacements {
+			replacement := &finding.Fix.Replacements[i]
+
+			hash, ok := hashes[replacement.FilePath]
+			if !ok {
+				content, err := os.ReadFile(replacement.FilePath)
+				if err != nil {
+					slog.Debug("could not hash file to fix", slog.String("file", replacement.FilePath), slog.String("error", err.Error()))
+				} else {
+					hash = contentHash(content)
+				}
+
+				hashes[replacement.FilePath] = hash
+			}
+
+			replacement.FileHash = hash
+		}
+	}
+}
+
+// Apply replacements to a file content, skipping the ones that are invalid, that overlap an already applied one, or
+// that refer to a previous content of the file.
+func applyReplacements(path string, content string, replacements []ci.Replacement) FileFix {
+	fileFix := FileFix{
+		Path:     path,
+		Original: content,
+		Fixed:    content,
+		Applied:  0,
+		Skipped:  0,
+	}
+
+	offsets := lineOffsets(content)
+	edits := []edit{}
+	hash := contentHash([]byte(content))
+
+	for _, replacement := range replacements {
+		// Positions of replacements made for another content may point anywhere in current one
+		if replacement.FileHash != "" && replacement.FileHash != hash {
+			slog.Warn("skipping replacement of a file that changed since it was checked", slog.String("file", path))
+
+			fileFix.Skipped++
+
+			continue
+		}
+
+		start, err := offset(content, offsets, replacement.StartLine, replacement.StartCol, false)
+		if err == nil {
+			var end int
+
+			end, err = offset(content, offsets, replacement.EndLine, replacement.EndCol, true)
+			if err == nil && end < start {
+				err = fmt.Errorf("end before start: %w", ErrInvalidReplacement)
+			}
+
+			if err == nil {
+				edits = append(edits, edit{start: start, end: end, text: replacement.Text})
+
+				continue
+			}
+		}
+
+		slog.Warn("skipping invalid replacement", slog.String("file", path), slog.String("error", err.Error()))
+
+		fileFix.Skipped++
+	}
+
+	slices.SortStableFunc(edits, func(a, b edit) int {
+		return cmp.Or(cmp.Compare(a.start, b.start), cmp.Compare(a.end, b.end))
+	})
+
+	var (
+		builder strings.Builder
+		last    *edit
+	)
+
+	cursor := 0
+
+	for i := range edits {
+		e := &edits[i]
+
+		if last != nil {
+			// Several tools may suggest the very same fix
+			if *e == *last {
+				continue
+			}
+
+			// Edits overlapping a previous one, as well as distinct insertions at the same position, are ambiguous
+			if e.start < last.end || (e.start == last.start && e.start == e.end && last.start == last.end) {
+				slog.Warn(
+					"skipping overlapping replacement",
+					slog.String("file", path),
+					slog.Int("start", e.start),
+					slog.Int("end", e.end),
+				)
+
+				fileFix.Skipped++
+
+				continue
+			}
+		}
+
+		builder.WriteString(content[cursor:e.start])
+		builder.WriteString(e.text)
+		cursor = e.end
+		last = e
+		fileFix.Applied++
+	}
+
+	builder.WriteString(content[cursor:])
+	fileFix.Fixed = builder.String()
+
+	return fileFix
+}
+
+// Compute fixed content of files, applying replacements of given findings fixes.
+func Compute(findings []ci.Finding) ([]FileFix, error) {
+	var pathOrder []string
+
+	byPath := map[string][]ci.Replacement{}
+
+	for _, finding := range findings {
+		if finding.Fix == nil {
+			continue
+		}
+
+		for _, replacement := range finding.Fix.Replacements {
+			if _, ok := byPath[replacement.FilePath]; !ok {
+				pathOrder = append(pathOrder, replacement.FilePath)
+			}
+
+			byPath[replacement.FilePath] = append(byPath[replacement.FilePath], replacement)
+		}
+	}
+
+	slices.Sort(pathOrder)
+
+	fixes := []FileFix{}
+
+	for _, path := range pathOrder {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %w", path, err)
+		}
+
+		fixes = append(fixes, applyReplacements(path, string(content), byPath[path]))
+	}
+
+	return fixes, nil
+}
+
+// Write fixed content of files in place.
+func Apply(fixes []FileFix) error {
+	for _, fileFix := range fixes {
+		if fileFix.Fixed == fileFix.Original {
+			continue
+		}
+
+		info, err := os.Stat(fileFix.Path)
+		if err != nil {
+			return fmt.Errorf("error getting file info of %s: %w", fileFix.Path, err)
+		}
+
+		err = os.WriteFile(fileFix.Path, []byte(fileFix.Fixed), info.Mode().Perm())
+		if err != nil {
+			return fmt.Errorf("error writing file %s: %w", fileFix.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Write fixes as a unified diff, that can be applied using `git apply`.
+func WriteDiff(w io.Writer, fixes []FileFix) error {
+	p := patch{filePatches: []fdiff.FilePatch{}}
+
+	for _, fileFix := range fixes {
+		if fileFix.Fixed == fileFix.Original {
+			continue
+		}
+
+		p.filePatches = append(p.filePatches, newFilePatch(fileFix))
+	}
+
+	err := fdiff.NewUnifiedEncoder(w, diffContextLines).Encode(p)
+	if err != nil {
+		return fmt.Errorf("error encoding diff: %w", err)
+	}
+
+	return nil
+}
+
+// Implementation of go-git diff interfaces, so that its unified diff encoder can be used
+
+type patch struct {
+	filePatches []fdiff.FilePatch
+}
+
+func (p patch) FilePatches() []fdiff.FilePatch {
+	return p.filePatches
+}
+
+func (p patch) Message() string {
+	return ""
+}
+
+type filePatch struct {
+	from   file
+	to     file
+	chunks []fdiff.Chunk
+}
+
+func newFilePatch(fileFix FileFix) filePatch {
+	chunks := []fdiff.Chunk{}
+
+	for _, d := range gdiff.Do(fileFix.Original, fileFix.Fixed) {
+		var op fdiff.Operation
+
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = fdiff.Equal
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		}
+
+		chunks = append(chunks, chunk{content: d.Text, op: op})
+	}
+
+	return filePatch{
+		from: file{
+			hash: plumbing.ComputeHash(plumbing.BlobObject, []byte(fileFix.Original)),
+			path: fileFix.Path,
+		},
+		to: file{
+			hash: plumbing.ComputeHash(plumbing.BlobObject, []byte(fileFix.Fixed)),
+			path: fileFix.Path,
+		},
+		chunks: chunks,
+	}
+}
+
+func (p filePatch) IsBinary() bool {
+	return false
+}
+
+func (p filePatch) Files() (fdiff.File, fdiff.File) {
+	return p.from, p.to
+}
+
+func (p filePatch) Chunks() []fdiff.Chunk {
+	return p.chunks
+}
+
+type file struct {
+	hash plumbing.Hash
+	path string
+}
+
+func (f file) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f file) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (f file) Path() string {
+	return f.path
+}
+
+type chunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c chunk) Content() string {
+	return c.content
+}
+
+func (c chunk) Type() fdiff.Operation {
+	return c.op
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+func TestApplyReplacements(t *testing.T) {
+	t.Parallel()
+
+	content := "first line\nsecond line\n"
+
+	tests := []struct {
+		name         string
+		replacements []ci.Replacement
+		wantFixed    string
+		wantApplied  int
+		wantSkipped  int
+	}{
+		{
+			name:         "replacement within a line",
+			replacements: []ci.Replacement{{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st"}},
+			wantFixed:    "1st line\nsecond line\n",
+			wantApplied:  1,
+			wantSkipped:  0,
+		},
+		{
+			name:         "insertion",
+			replacements: []ci.Replacement{{StartLine: 2, EndLine: 2, StartCol: 1, EndCol: 1, Text: "the "}},
+			wantFixed:    "first line\nthe second line\n",
+			wantApplied:  1,
+			wantSkipped:  0,
+		},
+		{
+			name:         "whole line",
+			replacements: []ci.Replacement{{StartLine: 2, EndLine: 2, StartCol: 0, EndCol: 0, Text: "last line"}},
+			wantFixed:    "first line\nlast line\n",
+			wantApplied:  1,
+			wantSkipped:  0,
+		},
+		{
+			name: "replacements on several lines",
+			replacements: []ci.Replacement{
+				{StartLine: 2, EndLine: 2, StartCol: 1, EndCol: 7, Text: "2nd"},
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st"},
+			},
+			wantFixed:   "1st line\n2nd line\n",
+			wantApplied: 2,
+			wantSkipped: 0,
+		},
+		{
+			name: "same replacement suggested twice",
+			replacements: []ci.Replacement{
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st"},
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st"},
+			},
+			wantFixed:   "1st line\nsecond line\n",
+			wantApplied: 1,
+			wantSkipped: 0,
+		},
+		{
+			name: "overlapping replacements",
+			replacements: []ci.Replacement{
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st"},
+				{StartLine: 1, EndLine: 1, StartCol: 3, EndCol: 11, Text: "rst"},
+			},
+			wantFixed:   "1st line\nsecond line\n",
+			wantApplied: 1,
+			wantSkipped: 1,
+		},
+		{
+			name:         "out of file bounds",
+			replacements: []ci.Replacement{{StartLine: 5, EndLine: 5, StartCol: 1, EndCol: 2, Text: "x"}},
+			wantFixed:    content,
+			wantApplied:  0,
+			wantSkipped:  1,
+		},
+		{
+			name:         "end before start",
+			replacements: []ci.Replacement{{StartLine: 2, EndLine: 1, StartCol: 1, EndCol: 1, Text: "x"}},
+			wantFixed:    content,
+			wantApplied:  0,
+			wantSkipped:  1,
+		},
+		{
+			name: "matching file hash",
+			replacements: []ci.Replacement{
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st", FileHash: contentHash([]byte(content))},
+			},
+			wantFixed:   "1st line\nsecond line\n",
+			wantApplied: 1,
+			wantSkipped: 0,
+		},
+		{
+			name: "stale file hash",
+			replacements: []ci.Replacement{
+				{StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 6, Text: "1st", FileHash: contentHash([]byte("previous content"))},
+			},
+			wantFixed:   content,
+			wantApplied: 0,
+			wantSkipped: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fileFix := applyReplacements("file", content, test.replacements)
+
+			if fileFix.Fixed != test.wantFixed {
+				t.Errorf("fixed content = %q, want %q", fileFix.Fixed, test.wantFixed)
+			}
+
+			if fileFix.Applied != test.wantApplied || fileFix.Skipped != test.wantSkipped {
+				t.Errorf(
+					"applied %d and skipped %d replacements, want %d and %d",
+					fileFix.Applied,
+					fileFix.Skipped,
+					test.wantApplied,
+					test.wantSkipped,
+				)
+			}
+		})
+	}
+}
+
+func TestComputeSkipsFilesChangedSinceCheck(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "unchanged.go")
+	changed := filepath.Join(dir, "changed.go")
+
+	for _, path := range []string{unchanged, changed} {
+		err := os.WriteFile(path, []byte("package main\n"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	finding := func(path string) ci.Finding {
+		return ci.Finding{
+			ToolName: "tool",
+			RuleID:   "rule",
+			FilePath: path,
+			Fix: &ci.Fix{Replacements: []ci.Replacement{
+				{FilePath: path, StartLine: 1, EndLine: 1, StartCol: 9, EndCol: 13, Text: "fixed"},
+			}},
+		}
+	}
+
+	findings := []ci.Finding{finding(unchanged), finding(changed)}
+
+	RecordFileHashes(findings)
+
+	// Another tool rewrites the file once it was checked
+	err := os.WriteFile(changed, []byte("// Package main\npackage main\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixes, err := Compute(findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		unchanged: "package fixed\n",
+		changed:   "// Package main\npackage main\n",
+	}
+
+	for _, fileFix := range fixes {
+		if fileFix.Fixed != want[fileFix.Path] {
+			t.Errorf("fixed content of %s = %q, want %q", fileFix.Path, fileFix.Fixed, want[fileFix.Path])
+		}
+	}
+}
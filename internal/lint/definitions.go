@@ -0,0 +1,140 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kemadev/ci-cd/pkg/ci"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrLinterDefinitionMissingField = fmt.Errorf("linter definition is missing a mandatory field")
+	ErrDuplicateLinterDefinition    = fmt.Errorf("linter is defined several times")
+)
+
+// LinterDefinition declares a linter command in a YAML file, so that linters can be added without rebuilding.
+type LinterDefinition struct {
+	// Command name, defaults to the file name without extension
+	Name string `yaml:"name"`
+	// Description shown in help message
+	Description string     `yaml:"description"`
+	Linter      LinterArgs `yaml:",inline"`
+}
+
+// Read linter definitions of all YAML files in given directory, sorted by name. A missing directory means no linter is
+// defined. Invalid definitions, including duplicate ones, are returned apart with their error by command name, for
+// them not to prevent other ones from being used.
+func ReadLinterDefinitions(dir string) ([]LinterDefinition, map[string]error, error) {
+	invalid := map[string]error{}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []LinterDefinition{}, invalid, nil
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading linter definitions directory: %w", err)
+	}
+
+	definitions := []LinterDefinition{}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		definition, err := ReadLinterDefinition(path)
+		if err != nil {
+			name := definition.Name
+			if name == "" {
+				name = strings.TrimSuffix(entry.Name(), ext)
+			}
+
+			invalid[name] = err
+
+			continue
+		}
+
+		if _, found := invalid[definition.Name]; found {
+			invalid[definition.Name] = fmt.Errorf("%s: %w", definition.Name, ErrDuplicateLinterDefinition)
+
+			continue
+		}
+
+		index := slices.IndexFunc(definitions, func(d LinterDefinition) bool { return d.Name == definition.Name })
+		if index != -1 {
+			definitions = slices.Delete(definitions, index, index+1)
+			invalid[definition.Name] = fmt.Errorf("%s: %w", definition.Name, ErrDuplicateLinterDefinition)
+
+			continue
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	slices.SortFunc(definitions, func(a, b LinterDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return definitions, invalid, nil
+}
+
+// Read linter definition of given YAML file, validating its mappings.
+func ReadLinterDefinition(path string) (LinterDefinition, error) {
+	definition, err := readLinterDefinition(path)
+	if err != nil {
+		return definition, fmt.Errorf("error loading linter definition %s: %w", path, err)
+	}
+
+	if definition.Name == "" {
+		definition.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return definition, nil
+}
+
+func readLinterDefinition(path string) (LinterDefinition, error) {
+	var definition LinterDefinition
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return definition, fmt.Errorf("error reading file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	// Typos in mappings would otherwise silently yield empty findings fields
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&definition)
+	if err != nil {
+		return definition, fmt.Errorf("error unmarshalling file: %w", err)
+	}
+
+	if definition.Linter.Bin == "" {
+		return definition, fmt.Errorf("bin: %w", ErrLinterDefinitionMissingField)
+	}
+
+	toolName := &definition.Linter.JSONInfo.Mappings.ToolName
+	if toolName.Key == "" && toolName.OverrideValue == "" {
+		toolName.OverrideValue = definition.Linter.Bin
+	}
+
+	_, err = ci.CompileJSONInfos(definition.Linter.JSONInfo)
+	if err != nil {
+		return definition, fmt.Errorf("error compiling mappings: %w", err)
+	}
+
+	return definition, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadLinterDefinitions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// Definitions which output is not parsed, as mappings are not the point here
+	none := "output:\n  type: none\n"
+
+	files := map[string]string{
+		"yamllint.yaml":      "bin: yamllint\n" + none,
+		"custom.yml":         "name: named\nbin: tool\n" + none,
+		"missing-bin.yaml":   "description: no binary\n",
+		"unknown-field.yaml": "bin: tool\nunknown: true\n",
+		"duplicate-a.yaml":   "name: duplicate\nbin: a\n" + none,
+		"duplicate-b.yaml":   "name: duplicate\nbin: b\n" + none,
+		"README.md":          "# Not a definition\n",
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	definitions, invalid, err := ReadLinterDefinitions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, definition := range definitions {
+		names = append(names, definition.Name)
+	}
+
+	if want := []string{"named", "yamllint"}; !slices.Equal(names, want) {
+		t.Errorf("definitions = %v, want %v", names, want)
+	}
+
+	for _, name := range []string{"missing-bin", "unknown-field", "duplicate"} {
+		if _, found := invalid[name]; !found {
+			t.Errorf("definition %s is not reported as invalid", name)
+		}
+	}
+
+	if len(invalid) != 3 {
+		t.Errorf("invalid definitions = %v, want 3 of them", invalid)
+	}
+
+	if !errors.Is(invalid["missing-bin"], ErrLinterDefinitionMissingField) {
+		t.Errorf("missing-bin error = %v, want %v", invalid["missing-bin"], ErrLinterDefinitionMissingField)
+	}
+
+	if !errors.Is(invalid["duplicate"], ErrDuplicateLinterDefinition) {
+		t.Errorf("duplicate error = %v, want %v", invalid["duplicate"], ErrDuplicateLinterDefinition)
+	}
+}
+
+func TestReadLinterDefinitionsMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	definitions, invalid, err := ReadLinterDefinitions(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || len(definitions) != 0 || len(invalid) != 0 {
+		t.Errorf("ReadLinterDefinitions() = %v, %v, %v, want no definition", definitions, invalid, err)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+// Reasons for which findings are not reported
+const (
+	FilteredSuppressed = "suppressed"
+	FilteredBaseline   = "baseline"
+	FilteredDiff       = "diff"
+)
+
+// Normalize findings and drop the ones that are not to be reported, returning remaining ones. Dropped findings are
+// kept track of for reports.
+func FilterFindings(config *config.Config, findings []ci.Finding) ([]ci.Finding, error) {
+	err := ci.NormalizeFindings(findings)
+	if err != nil {
+		return nil, fmt.Errorf("error normalizing findings: %w", err)
+	}
+
+	findings, suppressed := ci.ApplySuppressions(findings)
+	if len(suppressed) > 0 {
+		for _, finding := range suppressed {
+			slog.Info(
+				"finding suppressed",
+				slog.String("tool", finding.ToolName),
+				slog.String("rule", finding.RuleID),
+				slog.String("file", finding.FilePath),
+				slog.Int("line", finding.StartLine),
+			)
+		}
+
+		slog.Info("findings suppressed by inline directives are not reported", slog.Int("count", len(suppressed)))
+		config.Findings.AddFiltered(FilteredSuppressed, suppressed...)
+	}
+
+	if config.Suppressions != nil {
+		suppressions, err := config.Suppressions()
+		if err != nil {
+			return nil, err
+		}
+
+		findings, suppressed = suppressions.Apply(findings, time.Now())
+		if len(suppressed) > 0 {
+			slog.Info(
+				"findings suppressed by suppressions file are not reported",
+				slog.String("file", suppressions.Path),
+				slog.Int("count", len(suppressed)),
+			)
+			config.Findings.AddFiltered(FilteredSuppressed, suppressed...)
+		}
+	}
+
+	if config.Baseline != nil {
+		var baselined []ci.Finding
+
+		findings, baselined = config.Baseline.Filter(findings)
+		if len(baselined) > 0 {
+			slog.Info("findings part of baseline are not reported", slog.Int("count", len(baselined)))
+			config.Findings.AddFiltered(FilteredBaseline, baselined...)
+		}
+	}
+
+	if config.ChangedLines != nil {
+		var unchanged []ci.Finding
+
+		findings, unchanged = config.ChangedLines.Filter(findings)
+		if len(unchanged) > 0 {
+			slog.Info("findings unrelated to changed lines are not reported", slog.Int("count", len(unchanged)))
+			config.Findings.AddFiltered(FilteredDiff, unchanged...)
+		}
+	}
+
+	return findings, nil
+}
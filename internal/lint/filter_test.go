@@ -0,0 +1,88 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+func TestFilterFindingsSuppressionsFile(t *testing.T) {
+	t.Parallel()
+
+	findings := func() []ci.Finding {
+		return []ci.Finding{
+			{ToolName: "grype", RuleID: "CVE-2025-0001", Level: "error", FilePath: "go.mod", Message: "vulnerable"},
+			{ToolName: "grype", RuleID: "CVE-2025-0002", Level: "error", FilePath: "go.mod", Message: "vulnerable"},
+		}
+	}
+
+	t.Run("suppressed findings", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), ".suppressions.yaml")
+
+		err := os.WriteFile(path, []byte(`suppressions:
+  - tool: grype
+    rule: CVE-2025-0001
+    reason: not reachable
+    expires: 2999-01-01
+`), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conf := testConfig()
+		conf.Suppressions = func() (*ci.SuppressionsFile, error) {
+			return ci.ReadSuppressionsFile(path)
+		}
+
+		got, err := FilterFindings(conf, findings())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 || got[0].RuleID != "CVE-2025-0002" {
+			t.Errorf("FilterFindings() = %+v, want only CVE-2025-0002", got)
+		}
+
+		if suppressed := conf.Findings.Filtered(FilteredSuppressed); len(suppressed) != 1 {
+			t.Errorf("suppressed findings = %+v, want CVE-2025-0001", suppressed)
+		}
+	})
+
+	t.Run("invalid suppressions file", func(t *testing.T) {
+		t.Parallel()
+
+		errInvalid := fmt.Errorf("invalid suppressions file")
+
+		conf := testConfig()
+		conf.Suppressions = func() (*ci.SuppressionsFile, error) {
+			return nil, errInvalid
+		}
+
+		_, err := FilterFindings(conf, findings())
+		if !errors.Is(err, errInvalid) {
+			t.Errorf("FilterFindings() error = %v, want %v", err, errInvalid)
+		}
+	})
+
+	t.Run("no suppressions file", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := FilterFindings(testConfig(), findings())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 2 {
+			t.Errorf("FilterFindings() = %+v, want all findings", got)
+		}
+	})
+}
@@ -4,81 +4,161 @@
 package lint
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"os/exec"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/internal/fix"
 	"github.com/kemadev/ci-cd/pkg/ci"
 	"github.com/kemadev/ci-cd/pkg/filesfind"
 )
 
 type LinterArgs struct {
-	Bin      string
-	Ext      string
-	Paths    []string
-	CliArgs  []string
-	Workdir  string
-	JSONInfo ci.JSONInfos
+	Bin      string       `yaml:"bin"`
+	Ext      string       `yaml:"ext"`
+	Paths    []string     `yaml:"paths"`
+	CliArgs  []string     `yaml:"args"`
+	Workdir  string       `yaml:"workdir"`
+	JSONInfo ci.JSONInfos `yaml:"output"`
 	// Return non-zero exit code if at least one finding is found
-	FailOnAtLeastOneFinding bool
+	FailOnAtLeastOneFinding bool `yaml:"failOnAtLeastOneFinding"`
+	// Maximum duration of linter run, defaults to the configured one
+	Timeout time.Duration `yaml:"timeout"`
+	// Exit codes meaning that linter found findings, defaults to 1
+	FindingsExitCodes []int `yaml:"findingsExitCodes"`
+	// Output is a `go test -json` stream, allowing to report actual test results instead of findings
+	GoTestJSON bool `yaml:"-"`
+	// Only collect findings, for them to be printed along with the ones of other runs of the command
+	DeferPrinting bool `yaml:"-"`
 }
 
-var ErrNoLinterBinary = fmt.Errorf("linter binary is required")
+var (
+	ErrNoLinterBinary    = fmt.Errorf("linter binary is required")
+	ErrLinterInterrupted = fmt.Errorf("linter interrupted")
+)
+
+const (
+	// Duration given to linters to exit once terminated, before they are killed and their output is closed
+	killGracePeriod = 10 * time.Second
+	// Rule ID of findings reporting linters that did not complete within their timeout
+	TimeoutRuleID = "tool-timeout"
+)
+
+// Output of a linter run, which findings are decoded while it runs
+type linterOutput struct {
+	stdout      *outputTail
+	stderr      *outputTail
+	findings    []ci.Finding
+	decodeErr   error
+	goTestJunit *ci.GoTestJunit
+	// Timeout of the run, which was reached if linter was terminated
+	timeout    time.Duration
+	terminated *atomic.Bool
+}
 
+// Read pipe up to its end, passing it to parse function if any. Only a tail of the output is kept in memory.
 func processPipe(
 	config *config.Config,
 	pipe io.Reader,
-	buf *bytes.Buffer,
+	tail *outputTail,
 	output *os.File,
+	parse func(io.Reader),
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
 
-	reader := io.TeeReader(pipe, buf)
+	reader := io.TeeReader(pipe, tail)
 
 	if config.DebugEnabled {
-		_, err := io.Copy(output, reader)
-		if err != nil {
-			slog.Error("error copying to output", slog.Any("err", err))
-		}
-	} else {
-		_, err := io.Copy(io.Discard, reader)
-		if err != nil {
-			slog.Error("error reading pipe", slog.Any("err", err))
-		}
+		reader = io.TeeReader(reader, output)
+	}
+
+	if parse != nil {
+		parse(reader)
+	}
+
+	// Drain remaining output, e.g. following a decoding error, so that command does not block writing it
+	_, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		slog.Error("error reading pipe", slog.Any("err", err))
 	}
 }
 
+// Gracefully terminate process group of given leader. Group ID is the leader PID, which is only signaled while the
+// leader is not reaped, as it could otherwise be reused.
+func terminateProcessGroup(leader *os.Process) error {
+	err := leader.Signal(syscall.Signal(0))
+	if err != nil {
+		return fmt.Errorf("error checking linter process: %w", err)
+	}
+
+	slog.Warn("terminating linter", slog.Int("pid", leader.Pid))
+
+	err = syscall.Kill(-leader.Pid, syscall.SIGTERM)
+	if err != nil {
+		return fmt.Errorf("error terminating process group: %w", err)
+	}
+
+	return nil
+}
+
 func startCmd(
+	ctx context.Context,
 	config *config.Config,
 	lintArgs LinterArgs,
 	args []string,
-) (*sync.WaitGroup, *exec.Cmd, *bytes.Buffer, *bytes.Buffer, error) {
+	parse func(io.Reader),
+	terminated *atomic.Bool,
+) (func() error, *exec.Cmd, *outputTail, *outputTail, error) {
 	//nolint:gosec // We purposefully pass user controlled arguments, this script does not run outside of CI
 	// nosemgrep // Same
-	cmd := exec.Command(lintArgs.Bin, args...)
+	cmd := exec.CommandContext(ctx, lintArgs.Bin, args...)
+
+	// Linter gets its own process group, so that its children are terminated along with it instead of being orphaned.
+	// Linters still running once grace period elapsed are killed, and their output is closed, so that children holding
+	// it do not block the run any longer
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		err := terminateProcessGroup(cmd.Process)
+		if err != nil {
+			return err
+		}
 
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("error creating stdout pipe: %w", err)
-	}
+		terminated.Store(true)
 
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("error creating stderr pipe: %w", err)
+		return nil
 	}
+	cmd.WaitDelay = killGracePeriod
+
+	// Output is copied to pipes by command itself, so that it stops doing so once it is killed
+	stdoutPipe, stdoutWriter := io.Pipe()
+	stderrPipe, stderrWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
 
 	if lintArgs.Workdir != "" {
 		cmd.Dir = lintArgs.Workdir
 	}
 
-	var stdoutBuf, stderrBuf bytes.Buffer
+	var stdoutTail, stderrTail outputTail
+
+	// Findings are decoded from a single output
+	var stdoutParse, stderrParse func(io.Reader)
+	if lintArgs.JSONInfo.ReadFromStderr {
+		stderrParse = parse
+	} else {
+		stdoutParse = parse
+	}
 
 	var waitGroup sync.WaitGroup
 
@@ -90,31 +170,107 @@ func startCmd(
 
 	waitGroup.Add(numPipes)
 
-	go processPipe(config, stdoutPipe, &stdoutBuf, outputs[0], &waitGroup)
-	go processPipe(config, stderrPipe, &stderrBuf, outputs[1], &waitGroup)
+	go processPipe(config, stdoutPipe, &stdoutTail, outputs[0], stdoutParse, &waitGroup)
+	go processPipe(config, stderrPipe, &stderrTail, outputs[1], stderrParse, &waitGroup)
+
+	// Wait for command, then for its output to be processed
+	wait := func() error {
+		err := cmd.Wait()
+
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		waitGroup.Wait()
 
-	err = cmd.Start()
+		return err
+	}
+
+	err := cmd.Start()
 	if err != nil {
+		_ = wait()
+
 		return nil, nil, nil, nil, fmt.Errorf("error starting command: %w", err)
 	}
 
-	return &waitGroup, cmd, &stdoutBuf, &stderrBuf, nil
+	return wait, cmd, &stdoutTail, &stderrTail, nil
 }
 
-func GetOutputFormat() string {
+func GetOutputFormat(config *config.Config) string {
+	if config.OutputFormat != "" {
+		return config.OutputFormat
+	}
+
 	format := "human"
 	if os.Getenv("GITHUB_ACTIONS") != "" {
 		format = "github"
+	} else if os.Getenv("GITLAB_CI") != "" {
+		format = "gitlab"
 	}
 
 	return format
 }
 
-func RunLinter(config *config.Config, lintArgs LinterArgs) (int, string, string, error) {
+// Filter findings, print remaining ones using output format, and keep them for reports written once all
+// commands ran. Reported findings are returned.
+func ReportFindings(config *config.Config, findings []ci.Finding, format string) ([]ci.Finding, error) {
+	reported, err := FilterFindings(config, findings)
+	if err != nil {
+		return nil, err
+	}
+
+	err = printFindings(config, reported, format, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return reported, nil
+}
+
+// Whether JUnit output is requested, using output format or a report.
+func wantsJunit(conf *config.Config, format string) bool {
+	return format == "junit" || slices.ContainsFunc(conf.Reports, func(report config.ReportFile) bool {
+		return report.Format == "junit"
+	})
+}
+
+// Print findings, along with test suites of go test runs they were parsed from if any, and keep them for reports
+// written once all commands ran. Printing is deferred if requested by config, or by caller for this run only.
+func printFindings(config *config.Config, findings []ci.Finding, format string, goTests []*ci.GoTestJunit, deferPrinting bool) error {
+	config.Findings.Add(findings...)
+
+	for _, goTest := range goTests {
+		config.Findings.AddGoTestJunit(goTest)
+	}
+
+	if config.DeferPrinting || deferPrinting {
+		return nil
+	}
+
+	err := ci.PrintFindings(findings, format, goTests)
+	if err != nil {
+		return fmt.Errorf("error printing findings: %w", err)
+	}
+
+	return nil
+}
+
+// Run linter, decoding findings from its output as it is written. Exit code is returned, along with the tail of stdout and
+// stderr. Linter is terminated if ctx is done, or if it does not complete within its timeout, which is reported as a
+// finding.
+func RunLinter(ctx context.Context, config *config.Config, lintArgs LinterArgs) (int, string, string, error) {
 	if lintArgs.Bin == "" {
 		return 1, "", "", ErrNoLinterBinary
 	}
 
+	if lintArgs.JSONInfo.IsUnset() {
+		lintArgs.JSONInfo.Type = "none"
+	}
+
+	// Mappings errors are to surface before running the linter
+	plan, err := ci.CompileJSONInfos(lintArgs.JSONInfo)
+	if err != nil {
+		return 1, "", "", fmt.Errorf("error compiling mappings: %w", err)
+	}
+
 	files := []string{}
 
 	if lintArgs.Paths != nil {
@@ -144,7 +300,7 @@ func RunLinter(config *config.Config, lintArgs LinterArgs) (int, string, string,
 	args := lintArgs.CliArgs
 	args = append(args, files...)
 
-	format := GetOutputFormat()
+	format := GetOutputFormat(config)
 
 	slog.Debug("running linter",
 		slog.String("binary", lintArgs.Bin),
@@ -153,37 +309,149 @@ func RunLinter(config *config.Config, lintArgs LinterArgs) (int, string, string,
 		slog.Bool("failOnAtLeastOneFinding", lintArgs.FailOnAtLeastOneFinding),
 	)
 
-	waitGroup, cmd, stdoutBuf, stderrBuf, err := startCmd(config, lintArgs, args)
+	output := &linterOutput{
+		stdout:      nil,
+		stderr:      nil,
+		findings:    nil,
+		decodeErr:   nil,
+		goTestJunit: nil,
+		timeout:     lintArgs.Timeout,
+		terminated:  &atomic.Bool{},
+	}
+
+	if output.timeout == 0 {
+		output.timeout = config.Timeout
+	}
+
+	runCtx := ctx
+
+	if output.timeout > 0 {
+		var cancel context.CancelFunc
+
+		runCtx, cancel = context.WithTimeout(ctx, output.timeout)
+		defer cancel()
+	}
+
+	if lintArgs.GoTestJSON && wantsJunit(config, format) {
+		output.goTestJunit = ci.NewGoTestJunit(lintArgs.JSONInfo.Mappings.ToolName.OverrideValue)
+	}
+
+	var parse func(io.Reader)
+
+	if lintArgs.JSONInfo.Type != "none" && lintArgs.JSONInfo.Type != "plain" {
+		parse = func(r io.Reader) {
+			if output.goTestJunit != nil {
+				r = io.TeeReader(r, output.goTestJunit)
+			}
+
+			output.decodeErr = plan.Decode(r, func(finding ci.Finding) {
+				slog.Debug(
+					"finding decoded",
+					slog.String("tool", finding.ToolName),
+					slog.String("rule", finding.RuleID),
+					slog.String("file", finding.FilePath),
+				)
+
+				output.findings = append(output.findings, finding)
+			})
+		}
+	}
+
+	wait, cmd, stdoutTail, stderrTail, err := startCmd(runCtx, config, lintArgs, args, parse, output.terminated)
 	if err != nil {
 		return 1, "", "", fmt.Errorf("error preparing command: %w", err)
 	}
 
-	waitGroup.Wait()
+	output.stdout = stdoutTail
+	output.stderr = stderrTail
+
+	waitErr := wait()
 
-	rc, err := handleLinterOutcome(cmd, stdoutBuf, stderrBuf, format, lintArgs)
+	// Findings of interrupted linters are incomplete, and are not worth reporting
+	if ctx.Err() != nil {
+		return 1, stdoutTail.String(), stderrTail.String(), fmt.Errorf("%w: %w", ErrLinterInterrupted, ctx.Err())
+	}
+
+	rc, err := handleLinterOutcome(config, cmd, waitErr, output, format, lintArgs)
 	if err != nil {
 		return rc, "", "", fmt.Errorf("error handling linter outcome: %w", err)
 	}
 
-	return rc, stdoutBuf.String(), stderrBuf.String(), nil
+	return rc, stdoutTail.String(), stderrTail.String(), nil
+}
+
+// Whether given reported findings make command fail. Any finding does if no severity gate is set, otherwise only the
+// ones which level is part of it do.
+func ShouldFail(config *config.Config, findings []ci.Finding) bool {
+	if config.FailOn == nil {
+		return len(findings) > 0
+	}
+
+	return slices.ContainsFunc(findings, func(f ci.Finding) bool { return slices.Contains(config.FailOn, f.Level) })
+}
+
+// Finding reporting that linter did not complete within given timeout, and was terminated. It relates to the directory
+// linter ran in rather than to a file.
+func timeoutFinding(args LinterArgs, timeout time.Duration) ci.Finding {
+	toolName := args.JSONInfo.Mappings.ToolName.OverrideValue
+	if toolName == "" {
+		toolName = args.Bin
+	}
+
+	path := args.Workdir
+	if path == "" {
+		path = "."
+	}
+
+	return ci.Finding{
+		ToolName:    toolName,
+		RuleID:      TimeoutRuleID,
+		Level:       "error",
+		FilePath:    path,
+		StartLine:   0,
+		EndLine:     0,
+		StartCol:    0,
+		EndCol:      0,
+		Message:     fmt.Sprintf("%s did not complete within %s and was terminated, its findings may be incomplete", args.Bin, timeout),
+		Fingerprint: "",
+		HelpURI:     "",
+		Tags:        nil,
+		Category:    "timeout",
+		Fix:         nil,
+	}
+}
+
+// Whether linter exit code can be replaced by one based on reported findings, which is the case if linter succeeded, or
+// if it exited with a code meaning findings were found and findings were parsed. Other codes, such as crashes, are kept.
+func exitCodeReflectsFindings(retCode int, findings []ci.Finding, args LinterArgs) bool {
+	if retCode == 0 {
+		return true
+	}
+
+	findingsExitCodes := args.FindingsExitCodes
+	if len(findingsExitCodes) == 0 {
+		findingsExitCodes = []int{1}
+	}
+
+	return len(findings) > 0 && slices.Contains(findingsExitCodes, retCode)
 }
 
 func handleLinterOutcome(
+	config *config.Config,
 	cmd *exec.Cmd,
-	stdoutBuf *bytes.Buffer,
-	stderrBuf *bytes.Buffer,
+	waitErr error,
+	output *linterOutput,
 	format string,
 	args LinterArgs,
 ) (int, error) {
 	var findings []ci.Finding
 
-	err := cmd.Wait()
-	if err != nil {
+	if waitErr != nil {
 		slog.Error(
 			"command execution failed",
-			slog.String("error", err.Error()),
-			slog.String("stdout", stdoutBuf.String()),
-			slog.String("stderr", stderrBuf.String()),
+			slog.String("error", waitErr.Error()),
+			slog.String("stdout", output.stdout.String()),
+			slog.String("stderr", output.stderr.String()),
 		)
 	} else {
 		slog.Info("command executed successfully")
@@ -191,47 +459,81 @@ func handleLinterOutcome(
 
 	retCode := cmd.ProcessState.ExitCode()
 
-	switch args.JSONInfo.Type {
-	case "none":
+	switch {
+	case output.terminated.Load():
+		slog.Error(
+			"linter timed out",
+			slog.String("binary", args.Bin),
+			slog.Duration("timeout", output.timeout),
+		)
+
+		// Output of terminated linters is truncated, findings decoded so far are kept along with the timeout one
+		findings = append(findings, output.findings...)
+		findings = append(findings, timeoutFinding(args, output.timeout))
+	case args.JSONInfo.Type == "none":
 		slog.Debug(
 			"No finding parsing requested, skipping",
 			slog.String("type", args.JSONInfo.Type),
 		)
-	case "plain":
-		if len(stdoutBuf.String()) == 0 {
+	case args.JSONInfo.Type == "plain":
+		if output.stdout.Len() == 0 {
 			return 0, nil
 		}
 
 		find := ci.Finding{
-			ToolName:  args.JSONInfo.Mappings.ToolName.OverrideValue,
-			RuleID:    args.JSONInfo.Mappings.RuleID.OverrideValue,
-			Level:     args.JSONInfo.Mappings.Level.OverrideValue,
-			FilePath:  args.JSONInfo.Mappings.FilePath.OverrideValue,
-			Message:   args.JSONInfo.Mappings.Message.OverrideValue,
-			StartLine: 0,
-			EndLine:   0,
-			StartCol:  0,
-			EndCol:    0,
+			ToolName:    args.JSONInfo.Mappings.ToolName.OverrideValue,
+			RuleID:      args.JSONInfo.Mappings.RuleID.OverrideValue,
+			Level:       args.JSONInfo.Mappings.Level.OverrideValue,
+			FilePath:    args.JSONInfo.Mappings.FilePath.OverrideValue,
+			Message:     args.JSONInfo.Mappings.Message.OverrideValue,
+			StartLine:   0,
+			EndLine:     0,
+			StartCol:    0,
+			EndCol:      0,
+			Fingerprint: "",
+			HelpURI:     args.JSONInfo.Mappings.HelpURI.OverrideValue,
+			Tags:        nil,
+			Category:    args.JSONInfo.Mappings.Category.OverrideValue,
+			Fix:         nil,
 		}
 		findings = append(findings, find)
 	default:
-		var str string
-
-		if args.JSONInfo.ReadFromStderr {
-			str = stderrBuf.String()
-		} else {
-			str = stdoutBuf.String()
+		if output.decodeErr != nil {
+			return 1, fmt.Errorf("error parsing findings: %w", output.decodeErr)
 		}
 
-		fa, err := ci.FindingsFromJSON(str, args.JSONInfo)
-		if err != nil {
-			return 1, fmt.Errorf("error parsing findings: %w", err)
-		}
+		findings = append(findings, output.findings...)
+	}
+
+	reported, err := FilterFindings(config, findings)
+	if err != nil {
+		return 1, err
+	}
 
-		findings = append(findings, fa...)
+	if config.Fix || config.FixDiffPath != "" {
+		fix.RecordFileHashes(reported)
 	}
 
-	if args.FailOnAtLeastOneFinding && len(findings) > 0 {
+	switch {
+	case config.FailOn != nil && args.JSONInfo.Type != "none" && exitCodeReflectsFindings(retCode, findings, args):
+		// Unified severity gate takes precedence over tools exit code semantics, as long as it only means findings were
+		// found
+		retCode = 0
+		if ShouldFail(config, reported) {
+			slog.Error("findings found", slog.Any("failOn", config.FailOn))
+
+			retCode = 1
+		}
+	case !slices.EqualFunc(findings, reported, func(a, b ci.Finding) bool { return a.Fingerprint == b.Fingerprint }) &&
+		exitCodeReflectsFindings(retCode, findings, args):
+		// Tools exit code does not account for findings filtering, rely on reported ones instead
+		retCode = 0
+		if len(reported) > 0 {
+			retCode = 1
+		}
+	}
+
+	if config.FailOn == nil && args.FailOnAtLeastOneFinding && len(reported) > 0 {
 		slog.Error(
 			"findings found",
 			slog.Bool("FailOnAtLeastOneFinding", args.FailOnAtLeastOneFinding),
@@ -240,9 +542,20 @@ func handleLinterOutcome(
 		retCode = 1
 	}
 
-	err = ci.PrintFindings(findings, format)
+	// Checks of linters that timed out are incomplete, whatever their findings
+	if output.terminated.Load() {
+		retCode = 1
+	}
+
+	var goTests []*ci.GoTestJunit
+
+	if output.goTestJunit != nil {
+		goTests = append(goTests, output.goTestJunit)
+	}
+
+	err = printFindings(config, reported, format, goTests, args.DeferPrinting)
 	if err != nil {
-		return 1, fmt.Errorf("error printing findings: %w", err)
+		return 1, err
 	}
 
 	return retCode, nil
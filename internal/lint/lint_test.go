@@ -0,0 +1,183 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kemadev/ci-cd/internal/config"
+	"github.com/kemadev/ci-cd/pkg/ci"
+)
+
+func TestExitCodeReflectsFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []ci.Finding{{ToolName: "tool", RuleID: "rule", Level: "error", FilePath: "file", Message: "message"}}
+
+	tests := []struct {
+		name     string
+		retCode  int
+		findings []ci.Finding
+		args     LinterArgs
+		want     bool
+	}{
+		{name: "success", retCode: 0, findings: nil, args: LinterArgs{}, want: true},
+		{name: "findings found", retCode: 1, findings: findings, args: LinterArgs{}, want: true},
+		{name: "findings found without parsed findings", retCode: 1, findings: nil, args: LinterArgs{}, want: false},
+		{name: "crash", retCode: 3, findings: nil, args: LinterArgs{}, want: false},
+		{name: "crash with parsed findings", retCode: 3, findings: findings, args: LinterArgs{}, want: false},
+		{name: "killed", retCode: -1, findings: findings, args: LinterArgs{}, want: false},
+		{name: "declared findings exit code", retCode: 3, findings: findings, args: LinterArgs{FindingsExitCodes: []int{3}}, want: true},
+		{name: "default findings exit code overridden", retCode: 1, findings: findings, args: LinterArgs{FindingsExitCodes: []int{3}}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := exitCodeReflectsFindings(test.retCode, test.findings, test.args)
+			if got != test.want {
+				t.Errorf("exitCodeReflectsFindings(%d) = %t, want %t", test.retCode, got, test.want)
+			}
+		})
+	}
+}
+
+// Configuration of linter runs which findings are only collected.
+func testConfig() *config.Config {
+	return &config.Config{
+		OutputFormat:  "json",
+		Reports:       []config.ReportFile{},
+		Findings:      ci.NewCollector(),
+		DeferPrinting: true,
+	}
+}
+
+func TestRunLinterTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{name: "sleeping linter", script: "sleep 30"},
+		// Children are part of linter process group, and would otherwise keep its output open
+		{name: "sleeping children", script: "sleep 30 & sleep 30 & wait"},
+		// Killed once grace period elapsed, its output being closed even though children ignoring SIGTERM hold it
+		{name: "linter ignoring termination", script: "trap '' TERM; sleep 30"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := testConfig()
+			start := time.Now()
+
+			retCode, _, _, err := RunLinter(context.Background(), conf, LinterArgs{
+				Bin:      "sh",
+				CliArgs:  []string{"-c", test.script},
+				Timeout:  100 * time.Millisecond,
+				JSONInfo: ci.JSONInfos{Type: "none"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if elapsed := time.Since(start); elapsed > 2*killGracePeriod {
+				t.Errorf("linter ran for %s, it was not terminated", elapsed)
+			}
+
+			if retCode == 0 {
+				t.Error("RunLinter() exit code = 0, want non-zero")
+			}
+
+			findings := conf.Findings.Findings()
+			if len(findings) != 1 || findings[0].RuleID != TimeoutRuleID || findings[0].FilePath != "." {
+				t.Errorf("findings = %+v, want a single %s finding of repository", findings, TimeoutRuleID)
+			}
+		})
+	}
+}
+
+func TestRunLinterInterrupted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	conf := testConfig()
+	start := time.Now()
+
+	_, _, _, err := RunLinter(ctx, conf, LinterArgs{
+		Bin:      "sh",
+		CliArgs:  []string{"-c", "sleep 30"},
+		JSONInfo: ci.JSONInfos{Type: "none"},
+	})
+	if !errors.Is(err, ErrLinterInterrupted) {
+		t.Errorf("RunLinter() error = %v, want %v", err, ErrLinterInterrupted)
+	}
+
+	if elapsed := time.Since(start); elapsed > killGracePeriod {
+		t.Errorf("linter ran for %s, it was not terminated", elapsed)
+	}
+
+	// Findings of interrupted linters are not reported
+	if findings := conf.Findings.Findings(); len(findings) != 0 {
+		t.Errorf("findings = %+v, want none", findings)
+	}
+}
+
+func TestRunLinterCompletes(t *testing.T) {
+	t.Parallel()
+
+	conf := testConfig()
+
+	retCode, stdout, _, err := RunLinter(context.Background(), conf, LinterArgs{
+		Bin:      "sh",
+		CliArgs:  []string{"-c", "echo done"},
+		Timeout:  time.Minute,
+		JSONInfo: ci.JSONInfos{Type: "none"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if retCode != 0 || stdout != "done\n" {
+		t.Errorf("RunLinter() = %d, %q, want 0, %q", retCode, stdout, "done\n")
+	}
+}
+
+func TestGetOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		github string
+		gitlab string
+		want   string
+	}{
+		{name: "local", want: "human"},
+		{name: "github actions", github: "true", want: "github"},
+		{name: "gitlab ci", gitlab: "true", want: "gitlab"},
+		{name: "explicit format", format: "gitlab-codequality", gitlab: "true", want: "gitlab-codequality"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("GITHUB_ACTIONS", test.github)
+			t.Setenv("GITLAB_CI", test.gitlab)
+
+			conf := testConfig()
+			conf.OutputFormat = test.format
+
+			got := GetOutputFormat(conf)
+			if got != test.want {
+				t.Errorf("GetOutputFormat() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"fmt"
+)
+
+// Bytes of each output kept in memory for diagnostics, findings being decoded as output is written
+const outputTailSize = 64 * 1024
+
+// outputTail keeps the last bytes written to it, so that memory is bounded whatever the output size.
+type outputTail struct {
+	buf   []byte
+	total int
+}
+
+func (t *outputTail) Write(p []byte) (int, error) {
+	t.total += len(p)
+	t.buf = append(t.buf, p...)
+
+	if len(t.buf) > outputTailSize {
+		t.buf = append(t.buf[:0:0], t.buf[len(t.buf)-outputTailSize:]...)
+	}
+
+	return len(p), nil
+}
+
+// Count of bytes written, including the ones that are no longer kept.
+func (t *outputTail) Len() int {
+	return t.total
+}
+
+func (t *outputTail) String() string {
+	if t.total > len(t.buf) {
+		return fmt.Sprintf("[%d bytes truncated]", t.total-len(t.buf)) + string(t.buf)
+	}
+
+	return string(t.buf)
+}
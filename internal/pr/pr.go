@@ -32,7 +32,8 @@ func CheckPRTitle(title string) (ci.Finding, error) {
 			Level:    "error",
 			RuleID:   "pr-title-conventional-commit",
 			FilePath: "pr-title",
-			Message:  "PR title does not follow conventional commit format - See https://www.conventionalcommits.org",
+			Message:  "PR title does not follow conventional commit format",
+			HelpURI:  "https://www.conventionalcommits.org",
 		}, nil
 	}
 
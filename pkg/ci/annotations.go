@@ -6,6 +6,7 @@ package ci
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"slices"
@@ -18,7 +19,44 @@ var (
 	ErrInvalidFormat = fmt.Errorf("invalid format")
 )
 
-func printFindingsGithub(findings []*Finding) {
+// Details of the finding besides its location and message, one per line.
+func findingDetails(f *Finding) []string {
+	var details []string
+
+	if f.Category != "" {
+		details = append(details, "Category: "+f.Category)
+	}
+
+	if len(f.Tags) > 0 {
+		details = append(details, "Tags: "+strings.Join(f.Tags, ", "))
+	}
+
+	if f.HelpURI != "" {
+		details = append(details, "Help: "+f.HelpURI)
+	}
+
+	if f.Fix != nil {
+		fix := fmt.Sprintf("%d replacement(s)", len(f.Fix.Replacements))
+		if f.Fix.Description != "" {
+			fix = f.Fix.Description + ", " + fix
+		}
+
+		details = append(details, "Suggested fix: "+fix)
+	}
+
+	return details
+}
+
+// Message of the finding, pointing to the rule documentation if any and if message does not already.
+func messageWithHelp(f *Finding) string {
+	if f.HelpURI == "" || strings.Contains(f.Message, f.HelpURI) {
+		return f.Message
+	}
+
+	return f.Message + " - See " + f.HelpURI
+}
+
+func printFindingsGithub(w io.Writer, findings []*Finding) {
 	for _, annotation := range findings {
 		githubAnnotation := fmt.Sprintf(
 			"::%s title=%s,file=%s",
@@ -43,62 +81,120 @@ func printFindingsGithub(findings []*Finding) {
 			githubAnnotation += fmt.Sprintf(",endColumn=%d", annotation.EndCol)
 		}
 
-		quotedMessage := strconv.Quote(annotation.Message)
+		quotedMessage := strconv.Quote(messageWithHelp(annotation))
 		escapedMessage := quotedMessage[1 : len(quotedMessage)-1]
 		githubAnnotation += "::" + escapedMessage
 
-		fmt.Println(githubAnnotation)
+		fmt.Fprintln(w, githubAnnotation)
+	}
+}
+
+func printFindingsHuman(w io.Writer, findings []*Finding) {
+	for _, annotation := range findings {
+		fmt.Fprintf(w, "Tool: %s\n", annotation.ToolName)
+		fmt.Fprintf(w, "Rule ID: %s\n", annotation.RuleID)
+		fmt.Fprintf(w, "Level: %s\n", annotation.Level)
+		fmt.Fprintf(w, "File: %s", annotation.FilePath)
+
+		if annotation.StartLine > 0 {
+			fmt.Fprintf(w, ":%d", annotation.StartLine)
+		}
+
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Message: %s\n", annotation.Message)
+
+		for _, detail := range findingDetails(annotation) {
+			fmt.Fprintln(w, detail)
+		}
+
+		fmt.Fprintln(w)
 	}
 }
 
-func PrintFindings(findings []Finding, format string) error {
+func normalizeFindings(findings []Finding) ([]*Finding, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("error getting current working directory: %w", err)
+		return nil, fmt.Errorf("error getting current working directory: %w", err)
 	}
 
 	var pfindings []*Finding
 	for i := range findings {
 		pfindings = append(pfindings, &findings[i])
 		pfindings[i].FilePath = strings.TrimPrefix(pfindings[i].FilePath, cwd+"/")
+
+		if pfindings[i].Fix != nil {
+			for j := range pfindings[i].Fix.Replacements {
+				replacement := &pfindings[i].Fix.Replacements[j]
+				replacement.FilePath = strings.TrimPrefix(replacement.FilePath, cwd+"/")
+			}
+		}
 	}
 
 	err = validateFindings(pfindings)
 	if err != nil {
-		return fmt.Errorf("error validating findings: %w", err)
+		return nil, fmt.Errorf("error validating findings: %w", err)
 	}
 
-	if len(pfindings) == 0 {
-		slog.Info("no finding found")
+	computeFingerprints(pfindings)
 
-		return nil
-	}
+	return pfindings, nil
+}
+
+// Normalize findings in place, making paths relative to current working directory, mapping levels to supported
+// ones and computing fingerprints.
+func NormalizeFindings(findings []Finding) error {
+	_, err := normalizeFindings(findings)
 
+	return err
+}
+
+func writeFindings(w io.Writer, findings []*Finding, format string, goTests []*GoTestJunit) error {
 	switch format {
 	case "human":
-		for _, annotation := range pfindings {
-			fmt.Printf("Tool: %s\n", annotation.ToolName)
-			fmt.Printf("Rule ID: %s\n", annotation.RuleID)
-			fmt.Printf("Level: %s\n", annotation.Level)
-			fmt.Printf("File: %s", annotation.FilePath)
-
-			if annotation.StartLine > 0 {
-				fmt.Printf(":%d", annotation.StartLine)
-			}
-
-			fmt.Printf("\n")
-			fmt.Printf("Message: %s\n", annotation.Message)
-			fmt.Println()
-		}
+		printFindingsHuman(w, findings)
 	case "json":
-		output, err := json.MarshalIndent(pfindings, "", "  ")
+		output, err := json.MarshalIndent(findings, "", "  ")
 		if err != nil {
 			return fmt.Errorf("error marshalling findings to JSON: %w", err)
 		}
 
-		fmt.Println(string(output))
+		fmt.Fprintln(w, string(output))
 	case "github":
-		printFindingsGithub(pfindings)
+		printFindingsGithub(w, findings)
+	case "gitlab":
+		printFindingsGitlab(w, findings)
+	case "markdown":
+		printFindingsMarkdown(w, findings)
+	case "html":
+		err := printFindingsHTML(w, findings)
+		if err != nil {
+			return err
+		}
+	case "gitlab-codequality":
+		err := printFindingsCodeQuality(w, findings)
+		if err != nil {
+			return err
+		}
+	case "sarif":
+		err := printFindingsSarif(w, findings)
+		if err != nil {
+			return err
+		}
+	case "junit":
+		err := printFindingsJunit(w, findings, goTests)
+		if err != nil {
+			return err
+		}
+	case "checkstyle":
+		err := printFindingsCheckstyle(w, findings)
+		if err != nil {
+			return err
+		}
+	case "rdjson":
+		err := printFindingsRdjson(w, findings)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown output format %s: %w", format, ErrInvalidFormat)
 	}
@@ -106,6 +202,42 @@ func PrintFindings(findings []Finding, format string) error {
 	return nil
 }
 
+// Whether format outputs a single document, which is to be written even if there is no finding for it to stay valid,
+// and which logs must not be mixed with.
+func IsDocumentFormat(format string) bool {
+	return slices.Contains([]string{"json", "gitlab-codequality", "sarif", "junit", "checkstyle", "rdjson", "markdown", "html"}, format)
+}
+
+// Print findings to stdout in given format, findings are normalized in place. Nothing is printed if there is no finding,
+// unless format is a document one. Test suites of go test runs are part of JUnit output, in place of their findings.
+func PrintFindings(findings []Finding, format string, goTests []*GoTestJunit) error {
+	pfindings, err := normalizeFindings(findings)
+	if err != nil {
+		return err
+	}
+
+	if len(pfindings) == 0 {
+		slog.Info("no finding found")
+
+		if !IsDocumentFormat(format) {
+			return nil
+		}
+	}
+
+	return writeFindings(os.Stdout, pfindings, format, goTests)
+}
+
+// Write findings to w in given format, findings are normalized in place. Contrary to [PrintFindings], output is
+// written even if there is no finding, so that machine-readable documents are always valid.
+func WriteFindings(w io.Writer, findings []Finding, format string, goTests []*GoTestJunit) error {
+	pfindings, err := normalizeFindings(findings)
+	if err != nil {
+		return err
+	}
+
+	return writeFindings(w, pfindings, format, goTests)
+}
+
 func validateFindings(f []*Finding) error {
 	for _, annotation := range f {
 		if annotation.ToolName == "" {
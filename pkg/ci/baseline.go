@@ -0,0 +1,100 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const baselineVersion = 1
+
+var ErrUnsupportedBaselineVersion = fmt.Errorf("unsupported baseline version")
+
+// Baseline is a set of known findings, which are not to be reported, allowing adoption of tools on legacy code.
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+
+	fingerprints map[string]struct{}
+}
+
+// BaselineEntry identifies a finding by its fingerprint, other fields are informative and ease review of baseline changes.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	ToolName    string `json:"toolName"`
+	RuleID      string `json:"ruleID"`
+	FilePath    string `json:"filePath"`
+	Message     string `json:"message"`
+}
+
+func ReadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading baseline file: %w", err)
+	}
+
+	var baseline Baseline
+
+	err = json.Unmarshal(content, &baseline)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling baseline: %w", err)
+	}
+
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("version %d: %w", baseline.Version, ErrUnsupportedBaselineVersion)
+	}
+
+	baseline.fingerprints = map[string]struct{}{}
+	for _, entry := range baseline.Findings {
+		baseline.fingerprints[entry.Fingerprint] = struct{}{}
+	}
+
+	return &baseline, nil
+}
+
+// Write a baseline containing given findings, which must have been normalized.
+func WriteBaseline(w io.Writer, findings []Finding) error {
+	baseline := Baseline{
+		Version:      baselineVersion,
+		Findings:     []BaselineEntry{},
+		fingerprints: nil,
+	}
+
+	for _, finding := range findings {
+		baseline.Findings = append(baseline.Findings, BaselineEntry{
+			Fingerprint: finding.Fingerprint,
+			ToolName:    finding.ToolName,
+			RuleID:      finding.RuleID,
+			FilePath:    finding.FilePath,
+			Message:     finding.Message,
+		})
+	}
+
+	output, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling baseline: %w", err)
+	}
+
+	fmt.Fprintln(w, string(output))
+
+	return nil
+}
+
+// Split normalized findings into new ones and the ones that are part of the baseline.
+func (b *Baseline) Filter(findings []Finding) ([]Finding, []Finding) {
+	var kept, baselined []Finding
+
+	for _, finding := range findings {
+		if _, ok := b.fingerprints[finding.Fingerprint]; ok {
+			baselined = append(baselined, finding)
+		} else {
+			kept = append(kept, finding)
+		}
+	}
+
+	return kept, baselined
+}
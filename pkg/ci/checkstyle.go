@@ -0,0 +1,88 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const checkstyleVersion = "4.3"
+
+// See https://checkstyle.org, there is no formal schema, this follows what most consumers expect
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Map normalized finding levels (see validateFindings) to Checkstyle severities
+func checkstyleSeverity(level string) string {
+	switch level {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func checkstyleFromFindings(findings []*Finding) checkstyleReport {
+	report := checkstyleReport{
+		XMLName: xml.Name{Space: "", Local: ""},
+		Version: checkstyleVersion,
+		Files:   []checkstyleFile{},
+	}
+
+	fileIndexes := map[string]int{}
+
+	for _, finding := range findings {
+		fileIndex, ok := fileIndexes[finding.FilePath]
+		if !ok {
+			fileIndex = len(report.Files)
+			fileIndexes[finding.FilePath] = fileIndex
+
+			report.Files = append(report.Files, checkstyleFile{
+				Name:   finding.FilePath,
+				Errors: []checkstyleError{},
+			})
+		}
+
+		report.Files[fileIndex].Errors = append(report.Files[fileIndex].Errors, checkstyleError{
+			Line:     finding.StartLine,
+			Column:   finding.StartCol,
+			Severity: checkstyleSeverity(finding.Level),
+			Message:  messageWithHelp(finding),
+			Source:   finding.ToolName + "." + finding.RuleID,
+		})
+	}
+
+	return report
+}
+
+func printFindingsCheckstyle(w io.Writer, findings []*Finding) error {
+	output, err := xml.MarshalIndent(checkstyleFromFindings(findings), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling findings to Checkstyle: %w", err)
+	}
+
+	fmt.Fprintln(w, xml.Header+string(output))
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPrintFindingsCheckstyle(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{ToolName: "golangci-lint", RuleID: "errcheck", Level: "error", FilePath: "main.go", StartLine: 3, StartCol: 2, Message: "unchecked error"},
+		{ToolName: "hadolint", RuleID: "DL3008", Level: "notice", FilePath: "Dockerfile", StartLine: 1, Message: "pin versions"},
+		{ToolName: "golangci-lint", RuleID: "lll", Level: "warning", FilePath: "main.go", StartLine: 8, Message: "line is too long", HelpURI: "https://example.com/lll"},
+	}
+
+	var builder strings.Builder
+
+	err := printFindingsCheckstyle(&builder, findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(builder.String(), xml.Header) {
+		t.Errorf("output does not start with XML header:\n%s", builder.String())
+	}
+
+	var got checkstyleReport
+
+	err = xml.Unmarshal([]byte(builder.String()), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Errors are grouped by file, in order of appearance
+	want := checkstyleReport{
+		XMLName: xml.Name{Local: "checkstyle"},
+		Version: checkstyleVersion,
+		Files: []checkstyleFile{
+			{
+				Name: "main.go",
+				Errors: []checkstyleError{
+					{Line: 3, Column: 2, Severity: "error", Message: "unchecked error", Source: "golangci-lint.errcheck"},
+					{Line: 8, Severity: "warning", Message: "line is too long - See https://example.com/lll", Source: "golangci-lint.lll"},
+				},
+			},
+			{
+				Name:   "Dockerfile",
+				Errors: []checkstyleError{{Line: 1, Severity: "info", Message: "pin versions", Source: "hadolint.DL3008"}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkstyle report =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import "sync"
+
+// Collector gathers findings of commands that may run concurrently, so that they can be reported together.
+type Collector struct {
+	mu       sync.Mutex
+	findings []Finding
+	// Findings that were not reported, by reason
+	filtered map[string][]Finding
+	// Test suites of go test runs, for JUnit reports
+	goTests []*GoTestJunit
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		mu:       sync.Mutex{},
+		findings: []Finding{},
+		filtered: map[string][]Finding{},
+		goTests:  []*GoTestJunit{},
+	}
+}
+
+func (c *Collector) Add(findings ...Finding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.findings = append(c.findings, findings...)
+}
+
+// Findings returns a copy of collected findings.
+func (c *Collector) Findings() []Finding {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	findings := make([]Finding, len(c.findings))
+	copy(findings, c.findings)
+
+	return findings
+}
+
+// AddFiltered keeps track of findings that were not reported for given reason.
+func (c *Collector) AddFiltered(reason string, findings ...Finding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.filtered[reason] = append(c.filtered[reason], findings...)
+}
+
+// Filtered returns a copy of findings that were not reported for given reason.
+func (c *Collector) Filtered(reason string) []Finding {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	findings := make([]Finding, len(c.filtered[reason]))
+	copy(findings, c.filtered[reason])
+
+	return findings
+}
+
+// AddGoTestJunit keeps test suites of a go test run, for them to be part of JUnit reports.
+func (c *Collector) AddGoTestJunit(goTest *GoTestJunit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.goTests = append(c.goTests, goTest)
+}
+
+// GoTestJunits returns a copy of test suites builders of go test runs.
+func (c *Collector) GoTestJunits() []*GoTestJunit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	goTests := make([]*GoTestJunit, len(c.goTests))
+	copy(goTests, c.goTests)
+
+	return goTests
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrEquivalenceMissingField = fmt.Errorf("rule equivalence is missing a mandatory field")
+
+// RuleEquivalences holds classes of rules of different tools that detect the same kind of issue, so that findings they
+// yield at the same location can be merged.
+type RuleEquivalences struct {
+	Equivalences []RuleEquivalence `yaml:"equivalences"`
+}
+
+type RuleEquivalence struct {
+	// Identifier of the class, e.g. a CWE ID
+	ID string `yaml:"id"`
+	// Globs of rules part of the class, as `<tool>/<rule-id>` or `<tool>/<tag>`, tags identifying rules of tools which
+	// rule ID is too coarse, such as codes of gosec findings reported by golangci-lint
+	Rules []string `yaml:"rules"`
+
+	globs []glob.Glob
+}
+
+// Read rule equivalences file at given path, a missing file meaning findings are never merged.
+func ReadRuleEquivalences(path string) (*RuleEquivalences, error) {
+	equivalences := RuleEquivalences{
+		Equivalences: []RuleEquivalence{},
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &equivalences, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading rule equivalences file: %w", err)
+	}
+
+	err = yaml.Unmarshal(content, &equivalences)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling rule equivalences file: %w", err)
+	}
+
+	for i := range equivalences.Equivalences {
+		equivalence := &equivalences.Equivalences[i]
+
+		if equivalence.ID == "" || len(equivalence.Rules) == 0 {
+			return nil, fmt.Errorf("equivalence %d: %w", i, ErrEquivalenceMissingField)
+		}
+
+		for _, rule := range equivalence.Rules {
+			g, err := glob.Compile(rule)
+			if err != nil {
+				return nil, fmt.Errorf("error compiling rule %s of equivalence %s: %w", rule, equivalence.ID, err)
+			}
+
+			equivalence.globs = append(equivalence.globs, g)
+		}
+	}
+
+	return &equivalences, nil
+}
+
+// Identifier of the equivalence class of given finding rule or tags, if any.
+func (e *RuleEquivalences) classOf(f *Finding) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+
+	identifiers := []string{f.ToolName + "/" + f.RuleID}
+	for _, tag := range f.Tags {
+		identifiers = append(identifiers, f.ToolName+"/"+tag)
+	}
+
+	for _, equivalence := range e.Equivalences {
+		for _, g := range equivalence.globs {
+			for _, identifier := range identifiers {
+				if g.Match(identifier) {
+					return equivalence.ID, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Merge findings of different tools that point to the same location with equivalent rules into a single finding, which
+// message lists every tool that reported it. Merged finding is the first one reported, with the highest level of the
+// group. Findings that are not tied to a line are never merged.
+func DeduplicateFindings(findings []Finding, equivalences *RuleEquivalences) []Finding {
+	deduplicated := []Finding{}
+	groups := map[string]int{}
+	others := map[int][]string{}
+
+	for _, finding := range findings {
+		class, found := equivalences.classOf(&finding)
+		if !found || finding.StartLine <= 0 {
+			deduplicated = append(deduplicated, finding)
+
+			continue
+		}
+
+		key := strings.Join(
+			[]string{class, normalizeFingerprintPath(finding.FilePath), strconv.Itoa(finding.StartLine)},
+			"\x00",
+		)
+
+		index, grouped := groups[key]
+		if !grouped {
+			groups[key] = len(deduplicated)
+			deduplicated = append(deduplicated, finding)
+
+			continue
+		}
+
+		primary := &deduplicated[index]
+		if primary.ToolName == finding.ToolName {
+			// Same tool reporting several issues on a line, these are distinct findings
+			deduplicated = append(deduplicated, finding)
+
+			continue
+		}
+
+		if levelRank(finding.Level) < levelRank(primary.Level) {
+			primary.Level = finding.Level
+		}
+
+		reporter := finding.ToolName + "/" + finding.RuleID
+		if !slices.Contains(others[index], reporter) {
+			others[index] = append(others[index], reporter)
+		}
+	}
+
+	for index, reporters := range others {
+		deduplicated[index].Message += " (also reported by " + strings.Join(reporters, ", ") + ")"
+	}
+
+	return deduplicated
+}
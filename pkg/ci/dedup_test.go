@@ -0,0 +1,90 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"testing"
+)
+
+// Equivalences shipped as default config
+const defaultEquivalencesPath = "../../config/dedup/.equivalences.yaml"
+
+func TestDeduplicateFindings(t *testing.T) {
+	t.Parallel()
+
+	equivalences, err := ReadRuleEquivalences(defaultEquivalencesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finding := func(tool string, rule string, line int) Finding {
+		return Finding{ToolName: tool, RuleID: rule, Level: "warning", FilePath: "Dockerfile", StartLine: line, Message: rule}
+	}
+
+	tests := []struct {
+		name     string
+		findings []Finding
+		want     int
+	}{
+		{
+			name: "equivalent rules on same line",
+			findings: []Finding{
+				finding("hadolint", "DL3032", 3),
+				finding("sast-semgrep", "dockerfile.best-practice.missing-yum-clean-all.missing-yum-clean-all", 3),
+			},
+			want: 1,
+		},
+		{
+			name: "equivalent rules on different lines",
+			findings: []Finding{
+				finding("hadolint", "DL3032", 3),
+				finding("sast-semgrep", "dockerfile.best-practice.missing-yum-clean-all.missing-yum-clean-all", 4),
+			},
+			want: 2,
+		},
+		{
+			name: "distinct rules on same line",
+			findings: []Finding{
+				finding("hadolint", "DL3032", 3),
+				finding("sast-semgrep", "dockerfile.best-practice.missing-apk-no-cache.missing-apk-no-cache", 3),
+			},
+			want: 2,
+		},
+		{
+			name: "semgrep and gosec rules on same line",
+			findings: []Finding{
+				{ToolName: "golangci-lint", RuleID: "gosec", Level: "warning", FilePath: "db.go", StartLine: 7, Message: "G201: SQL string formatting", Tags: []string{"G201"}},
+				{ToolName: "sast-semgrep", RuleID: "go.lang.security.audit.database.string-formatted-query.string-formatted-query", Level: "error", FilePath: "./db.go", StartLine: 7, Message: "SQL injection"},
+			},
+			want: 1,
+		},
+		{
+			name: "distinct gosec rules on same line",
+			findings: []Finding{
+				{ToolName: "golangci-lint", RuleID: "gosec", Level: "warning", FilePath: "db.go", StartLine: 7, Message: "G104: Errors unhandled", Tags: []string{"G104"}},
+				{ToolName: "sast-semgrep", RuleID: "go.lang.security.audit.database.string-formatted-query.string-formatted-query", Level: "error", FilePath: "./db.go", StartLine: 7, Message: "SQL injection"},
+			},
+			want: 2,
+		},
+		{
+			name: "distinct security rules on same line",
+			findings: []Finding{
+				finding("golangci-lint", "gosec", 3),
+				finding("sast-semgrep", "go.lang.security.audit.xss.no-direct-write-to-responsewriter.no-direct-write-to-responsewriter", 3),
+			},
+			want: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := DeduplicateFindings(test.findings, equivalences)
+			if len(got) != test.want {
+				t.Errorf("got %d findings, want %d: %v", len(got), test.want, got)
+			}
+		})
+	}
+}
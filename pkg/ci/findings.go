@@ -4,9 +4,9 @@
 package ci
 
 import (
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,13 +22,42 @@ type Finding struct {
 	StartCol  int    `json:"startCol"`
 	EndCol    int    `json:"endCol"`
 	Message   string `json:"message"`
+	// Stable identifier of the finding, computed when findings are normalized if not set
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// URI of the rule documentation
+	HelpURI string `json:"helpURI,omitempty"`
+	// Tags of the finding, such as CWE IDs
+	Tags []string `json:"tags,omitempty"`
+	// Category of the finding, such as `security`, `vulnerability`, `secret`, `lint`, `test`, `coverage`, `dependency`
+	Category string `json:"category,omitempty"`
+	// Suggested fix of the finding, if any
+	Fix *Fix `json:"fix,omitempty"`
+}
+
+// Fix is a set of replacements to apply to files in order to fix a finding.
+type Fix struct {
+	Description  string        `json:"description,omitempty"`
+	Replacements []Replacement `json:"replacements"`
+}
+
+// Replacement replaces text between start and end positions with given text. Lines and columns are 1-based, end column
+// being exclusive, so that a replacement which start and end positions are the same is an insertion.
+type Replacement struct {
+	FilePath  string `json:"filePath"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	StartCol  int    `json:"startCol"`
+	EndCol    int    `json:"endCol"`
+	Text      string `json:"text"`
+	// SHA-256 of the file content positions refer to, replacement being skipped if file changed since
+	FileHash string `json:"fileHash,omitempty"`
 }
 
 type JSONToFindingsMappings struct {
 	// Key containing the array of findings in which to search using jsonMappingInfo
-	BaseArrayKey string
-	ToolName     JSONMappingInfo
-	RuleID       JSONMappingInfo
+	BaseArrayKey string          `yaml:"baseArrayKey"`
+	ToolName     JSONMappingInfo `yaml:"toolName"`
+	RuleID       JSONMappingInfo `yaml:"ruleId"`
 	// Severity level of the finding, valid values are `debug`, `notice`, `warning`, `error`
 	// Based on GitHub workflow commands, see https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/workflow-commands-for-github-actions#setting-a-debug-message
 	// Other common values are mapped automatically:
@@ -37,47 +66,86 @@ type JSONToFindingsMappings struct {
 	// `medium` -> `warning`
 	// `critical` -> `error`
 	// `high` -> `error`
-	Level     JSONMappingInfo
-	FilePath  JSONMappingInfo
-	StartLine JSONMappingInfo
-	EndLine   JSONMappingInfo
-	StartCol  JSONMappingInfo
-	EndCol    JSONMappingInfo
-	Message   JSONMappingInfo
+	Level     JSONMappingInfo `yaml:"level"`
+	FilePath  JSONMappingInfo `yaml:"filePath"`
+	StartLine JSONMappingInfo `yaml:"startLine"`
+	EndLine   JSONMappingInfo `yaml:"endLine"`
+	StartCol  JSONMappingInfo `yaml:"startCol"`
+	EndCol    JSONMappingInfo `yaml:"endCol"`
+	Message   JSONMappingInfo `yaml:"message"`
+	HelpURI   JSONMappingInfo `yaml:"helpUri"`
+	// Keys whose value is an array are converted to one tag per item, override and default values are comma-separated
+	Tags     JSONMappingInfo `yaml:"tags"`
+	Category JSONMappingInfo `yaml:"category"`
+	Fix      JSONFixMappings `yaml:"fix"`
+}
+
+type JSONFixMappings struct {
+	// Key containing the array of replacements of the finding. If empty, the finding holds a single replacement, which
+	// is only set if Text key is found, allowing replacements with an empty text (deletions)
+	BaseArrayKey string          `yaml:"baseArrayKey"`
+	Description  JSONMappingInfo `yaml:"description"`
+	// Replacement position, defaults to the finding one when not found
+	FilePath  JSONMappingInfo `yaml:"filePath"`
+	StartLine JSONMappingInfo `yaml:"startLine"`
+	EndLine   JSONMappingInfo `yaml:"endLine"`
+	StartCol  JSONMappingInfo `yaml:"startCol"`
+	EndCol    JSONMappingInfo `yaml:"endCol"`
+	Text      JSONMappingInfo `yaml:"text"`
 }
 
 type JSONMappingInfo struct {
 	// JSON key to find, can use dot notation to find nested keys like `foo.bar.baz`. Keys whose value is a string array will be
 	// converted to a string by joining the values with " - ".
-	Key string
+	// Arrays met on the way resolve to their first element, other elements can be selected by index as in `foo[2].bar` or
+	// `foo[-1].bar`, or using a filter as in `foo[?bar.baz=="qux"].bar`, which selects the first matching element. Filters
+	// support `==`, `!=`, `=~` (regex) operators, or only check existence as in `foo[?bar]`. Keys containing dots can be
+	// escaped as in `foo\.bar` or quoted as in `["foo.bar"]`, see parseQuery.
+	// Arrays can be fanned out using a wildcard, as in `locations[*].path`, yielding one finding per element. Other
+	// mappings using the same array resolve against the same element.
+	Key string `yaml:"key"`
+	// Return an error if key is not found, instead of silently using the default value
+	Required bool `yaml:"required"`
 	// Value to use if the key is not found or is empty. Internally uses strconv.Atoi to convert the value to an int if mapping type is int
-	DefaultValue string
+	DefaultValue string `yaml:"defaultValue"`
 	// Do not try to find key and use this value instead
-	OverrideValue string
+	OverrideValue string `yaml:"overrideValue"`
 	// Transform found value using this regex
-	ValueTransformerRegex string
+	ValueTransformerRegex string `yaml:"valueTransformerRegex"`
 	// Discard whole finding if this regex for current key does not match
-	GlobalSelectorRegex string
+	GlobalSelectorRegex string `yaml:"globalSelectorRegex"`
 	// Select if the regex does not match (kind-of global negative lookahead, not supported in Go)
-	InvertGlobalSelector bool
+	InvertGlobalSelector bool `yaml:"invertGlobalSelector"`
 	// Another jsonMappingInfo to use as a suffix
 	// Can be used to compose a value from multiple sources, like <mapping1 result><mapping2 result><mapping3 result>
 	// Use in conjunction with OverrideKey to set constant values
 	// Only enabled for string values
-	Suffix *JSONMappingInfo
+	Suffix *JSONMappingInfo `yaml:"suffix"`
 }
 
 type JSONInfos struct {
 	// Type or linter output to parse
-	// Default is handling json array of findings
+	// Default is handling json array of findings, unless no field of JSONInfos is set, which is the same as "none"
 	// "plain": Handle plain text output, where any output is considered a finding, with such finding
 	// being populated with the OverrideKey values from the jsonMappingInfo
 	// "stream": Handle JSON stream output, internally converted to simple JSON array
 	// "none": Do not parse output, do not treat it as finding
-	Type string
+	// "sarif": Handle SARIF log, results being parsed without need for mappings, except for ToolName and Category
+	// override values
+	// "regex": Handle text output using Regex, each match being a finding, see decodeRegexFindings
+	// "checkstyle-xml": Handle Checkstyle XML document, see decodeCheckstyleXMLFindings
+	// "junit-xml": Handle JUnit XML document, each failing test being a finding, see decodeJUnitXMLFindings
+	Type string `yaml:"type"`
+	// Regex applied to text output when Type is "regex", which named groups are used as JSON keys
+	Regex string `yaml:"regex"`
 	// Whether to read the JSON from stderr instead of stdout
-	ReadFromStderr bool
-	Mappings       JSONToFindingsMappings
+	ReadFromStderr bool                   `yaml:"readFromStderr"`
+	Mappings       JSONToFindingsMappings `yaml:"mappings"`
+}
+
+// Whether no field is set, as for commands which output is not to be parsed, such JSONInfos being handled as type "none".
+func (j JSONInfos) IsUnset() bool {
+	return reflect.DeepEqual(j, JSONInfos{})
 }
 
 var (
@@ -91,61 +159,22 @@ var (
 	ErrJSONNoSuchKey       = fmt.Errorf("json does not contain key")
 )
 
+// Parse findings from given tool output, according to its type and mappings.
 func FindingsFromJSON(str string, jsonInfo JSONInfos) ([]Finding, error) {
-	if str == "" {
-		return nil, nil
-	}
-
-	switch jsonInfo.Type {
-	case "stream":
-		strAsArray := ""
-
-		for _, line := range strings.Split(str, "\n") {
-			if strings.TrimSpace(line) != "" {
-				strAsArray += line + ","
-			}
-		}
-
-		strAsArray = "[" + strings.TrimSuffix(strAsArray, ",") + "]"
-		str = strAsArray
-	case "object":
-		str = "[" + str + "]"
-	}
-
-	var jsonm any
-
-	err := json.Unmarshal([]byte(str), &jsonm)
+	plan, err := CompileJSONInfos(jsonInfo)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling json: %w", err)
-	}
-
-	if jsonInfo.Mappings.BaseArrayKey != "" {
-		njsonm, ok := jsonm.(map[string]any)
-		if !ok {
-			return nil, fmt.Errorf(
-				"json does not contain key %s: %w",
-				jsonInfo.Mappings.BaseArrayKey,
-				ErrJSONNoSuchKey,
-			)
-		}
-
-		jsonm = njsonm[jsonInfo.Mappings.BaseArrayKey]
+		return nil, fmt.Errorf("error compiling mappings: %w", err)
 	}
 
-	jsonArray, ok := jsonm.([]any)
-	if !ok {
-		return nil, ErrJSONNotArray
-	}
+	return plan.FindingsFromJSON(str)
+}
 
+// Parse findings from a single JSON object, fanning out arrays referenced by mappings keys.
+func findingsFromObject(m map[string]any, plan *FindingsPlan) ([]Finding, error) {
 	var findings []Finding
 
-	for _, item := range jsonArray {
-		m, ok := item.(map[string]any)
-		if !ok {
-			return nil, ErrJSONNotArray
-		}
-
-		keep, finding, err := findingFromJSONObject(m, jsonInfo.Mappings)
+	for _, view := range fanOut(m, plan, map[string]struct{}{}) {
+		keep, finding, err := findingFromJSONObject(view, plan.mappings)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing json object: %w", err)
 		}
@@ -160,167 +189,206 @@ func FindingsFromJSON(str string, jsonInfo JSONInfos) ([]Finding, error) {
 
 func findingFromJSONObject(
 	jsonm map[string]any,
-	mappings JSONToFindingsMappings,
+	mappings compiledMappings,
 ) (bool, Finding, error) {
 	var finding Finding
 
 	mappingFields := []struct {
-		mapping JSONMappingInfo
+		mapping *compiledMapping
 		field   any
 	}{
-		{mappings.ToolName, &finding.ToolName},
-		{mappings.RuleID, &finding.RuleID},
-		{mappings.Level, &finding.Level},
-		{mappings.FilePath, &finding.FilePath},
-		{mappings.StartLine, &finding.StartLine},
-		{mappings.EndLine, &finding.EndLine},
-		{mappings.StartCol, &finding.StartCol},
-		{mappings.EndCol, &finding.EndCol},
-		{mappings.Message, &finding.Message},
+		{mappings.toolName, &finding.ToolName},
+		{mappings.ruleID, &finding.RuleID},
+		{mappings.level, &finding.Level},
+		{mappings.filePath, &finding.FilePath},
+		{mappings.startLine, &finding.StartLine},
+		{mappings.endLine, &finding.EndLine},
+		{mappings.startCol, &finding.StartCol},
+		{mappings.endCol, &finding.EndCol},
+		{mappings.message, &finding.Message},
+		{mappings.helpURI, &finding.HelpURI},
+		{mappings.tags, &finding.Tags},
+		{mappings.category, &finding.Category},
 	}
 
 	for _, mf := range mappingFields {
 		shouldKeep, err := setValue(jsonm, mf.mapping, mf.field)
 		if err != nil {
-			return false, finding, fmt.Errorf("error setting value for %v: %w", mf.mapping.Key, err)
+			return false, finding, fmt.Errorf("error setting value for %v: %w", mf.mapping.info.Key, err)
 		}
 
 		if !shouldKeep {
 			return false, Finding{
-				ToolName:  "",
-				RuleID:    "",
-				Level:     "",
-				FilePath:  "",
-				StartLine: 0,
-				EndLine:   0,
-				StartCol:  0,
-				EndCol:    0,
-				Message:   "",
+				ToolName:    "",
+				RuleID:      "",
+				Level:       "",
+				FilePath:    "",
+				StartLine:   0,
+				EndLine:     0,
+				StartCol:    0,
+				EndCol:      0,
+				Message:     "",
+				Fingerprint: "",
+				HelpURI:     "",
+				Tags:        nil,
+				Category:    "",
+				Fix:         nil,
 			}, nil
 		}
 	}
 
 	finding.Level = strings.ToLower(finding.Level)
 
+	fix, err := fixFromJSONObject(jsonm, mappings.fix, &finding)
+	if err != nil {
+		return false, finding, fmt.Errorf("error parsing fix: %w", err)
+	}
+
+	finding.Fix = fix
+
 	return true, finding, nil
 }
 
-func applyGlobalSelector(jsonm map[string]any, mapInfo JSONMappingInfo) (bool, error) {
-	if jsonm[mapInfo.Key] == nil {
-		return false, nil
-	}
+func fixFromJSONObject(jsonm map[string]any, mappings compiledFixMappings, finding *Finding) (*Fix, error) {
+	var items []map[string]any
 
-	if s, ok := jsonm[mapInfo.Key].(string); ok {
-		if s == "" {
-			return false, nil
-		}
+	if mappings.baseArrayKey != "" {
+		value, _ := evalQuery(jsonm, mappings.baseArraySteps)
+		array, _ := value.([]any)
 
-		res, err := globalSelectFromRegex(s, mapInfo.GlobalSelectorRegex)
-		if err != nil {
-			return false, fmt.Errorf("error applying global selector regex: %w", err)
-		}
+		for _, item := range array {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: %w", mappings.baseArrayKey, ErrJSONNotMap)
+			}
 
-		if mapInfo.InvertGlobalSelector {
-			res = !res
+			items = append(items, m)
 		}
-
-		if !res {
-			return false, nil
+	} else if mappings.text.info.Key != "" {
+		if _, found := evalQuery(jsonm, mappings.text.steps); found {
+			items = append(items, jsonm)
 		}
 	}
 
-	return true, nil
-}
+	if len(items) == 0 {
+		return nil, nil
+	}
 
-func setValue(
-	jsonm map[string]any,
-	mapInfo JSONMappingInfo,
-	field any,
-) (bool, error) {
-	jsonTargetKey := jsonm
+	fix := Fix{
+		Description:  "",
+		Replacements: []Replacement{},
+	}
 
-	if strings.Contains(mapInfo.Key, ".") {
-		keys := strings.Split(mapInfo.Key, ".")
-		for _, key := range keys {
-			if jsonTargetKey[key] == nil {
-				break
-			}
+	_, err := setValue(jsonm, mappings.description, &fix.Description)
+	if err != nil {
+		return nil, fmt.Errorf("error setting value for %v: %w", mappings.description.info.Key, err)
+	}
+
+	for _, item := range items {
+		replacement := Replacement{
+			FilePath:  "",
+			StartLine: 0,
+			EndLine:   0,
+			StartCol:  0,
+			EndCol:    0,
+			Text:      "",
+			FileHash:  "",
+		}
+
+		mappingFields := []struct {
+			mapping *compiledMapping
+			field   any
+		}{
+			{mappings.filePath, &replacement.FilePath},
+			{mappings.startLine, &replacement.StartLine},
+			{mappings.endLine, &replacement.EndLine},
+			{mappings.startCol, &replacement.StartCol},
+			{mappings.endCol, &replacement.EndCol},
+			{mappings.text, &replacement.Text},
+		}
 
-			jsonTargetKey = handleKeyType(jsonTargetKey, key)
-			if jsonTargetKey == nil {
-				return false, fmt.Errorf(
-					"error converting %s to a valid type: %w",
-					key,
-					ErrCantConvert,
-				)
+		for _, mf := range mappingFields {
+			_, err := setValue(item, mf.mapping, mf.field)
+			if err != nil {
+				return nil, fmt.Errorf("error setting value for %v: %w", mf.mapping.info.Key, err)
 			}
 		}
 
-		mapInfo.Key = keys[len(keys)-1]
-	}
+		if replacement.FilePath == "" {
+			replacement.FilePath = finding.FilePath
+		}
 
-	if mapInfo.GlobalSelectorRegex != "" {
-		shouldKeep, err := applyGlobalSelector(jsonTargetKey, mapInfo)
-		if err != nil || !shouldKeep {
-			return false, err
+		if replacement.StartLine == 0 {
+			replacement.StartLine = finding.StartLine
+			replacement.StartCol = finding.StartCol
+			replacement.EndLine = max(finding.EndLine, finding.StartLine)
+			replacement.EndCol = finding.EndCol
 		}
-	}
 
-	switch v := field.(type) {
-	case *string:
-		return true, setStringValue(jsonTargetKey, jsonm, mapInfo, v)
-	case *int:
-		return true, setIntValue(jsonTargetKey, mapInfo, v)
-	default:
-		return false, fmt.Errorf("unsupported type %T: %w", field, ErrUnsupportedType)
+		if replacement.EndLine == 0 {
+			replacement.EndLine = replacement.StartLine
+		}
+
+		fix.Replacements = append(fix.Replacements, replacement)
 	}
+
+	return &fix, nil
 }
 
-func handleKeyType(jsonm map[string]any, key string) map[string]any {
-	switch value := jsonm[key].(type) {
-	case map[string]any:
-		return value
-	case []any:
-		if len(value) == 0 {
-			return nil
-		}
+func applyGlobalSelector(value any, mapping *compiledMapping) bool {
+	if value == nil {
+		return false
+	}
 
-		m, ok := value[0].(map[string]any)
-		if ok {
-			return m
+	if s, ok := value.(string); ok {
+		if s == "" {
+			return false
 		}
 
-		handleKeyType(m, key)
+		res := mapping.selector.MatchString(s)
 
-		return nil
-	case string:
-		return map[string]any{key: value}
-	case int:
-		return map[string]any{key: value}
-	case float64:
-		return map[string]any{key: int(value)}
-	case bool:
-		return map[string]any{key: value}
-	default:
-		return nil
+		if mapping.info.InvertGlobalSelector {
+			res = !res
+		}
+
+		if !res {
+			return false
+		}
 	}
+
+	return true
 }
 
-func globalSelectFromRegex(str, regex string) (bool, error) {
-	exp, err := regexp.Compile(regex)
-	if err != nil {
-		return false, fmt.Errorf("error compiling regex: %w", err)
-	}
+func setValue(
+	jsonm map[string]any,
+	mapping *compiledMapping,
+	field any,
+) (bool, error) {
+	var value any
+
+	if mapping.info.Key != "" {
+		found := false
 
-	if exp == nil {
-		return false, ErrCantCompileRegex
+		value, found = evalQuery(jsonm, mapping.steps)
+		if !found && mapping.info.Required && mapping.info.OverrideValue == "" {
+			return false, fmt.Errorf("%s: %w", mapping.info.Key, ErrJSONNoSuchKey)
+		}
 	}
 
-	if str == "" {
+	if mapping.selector != nil && !applyGlobalSelector(value, mapping) {
 		return false, nil
 	}
 
-	return exp.MatchString(str), nil
+	switch v := field.(type) {
+	case *string:
+		return true, setStringValue(value, jsonm, mapping, v)
+	case *int:
+		return true, setIntValue(value, mapping, v)
+	case *[]string:
+		return true, setStringSliceValue(value, mapping, v)
+	default:
+		return false, fmt.Errorf("unsupported type %T: %w", field, ErrUnsupportedType)
+	}
 }
 
 func getDefaultStringValue(i JSONMappingInfo, defaultValue string) string {
@@ -328,47 +396,30 @@ func getDefaultStringValue(i JSONMappingInfo, defaultValue string) string {
 		return i.OverrideValue
 	}
 
-	if i.Key == "" {
-		return defaultValue
-	}
-
-	return defaultValue
-}
-
-func getDefaultIntValue(i JSONMappingInfo, defaultValue int) int {
-	if i.Key == "" {
-		return defaultValue
-	}
-
 	return defaultValue
 }
 
-func applyValueTransformerRegex(val, regex string) (string, error) {
-	if regex == "" {
-		return val, nil
+func applyValueTransformerRegex(val string, transformer *regexp.Regexp) string {
+	if transformer == nil {
+		return val
 	}
 
-	r, err := regexp.Compile(regex)
-	if err != nil {
-		return "", fmt.Errorf("error compiling regex: %w", err)
-	}
-
-	m := r.FindStringSubmatch(val)
+	m := transformer.FindStringSubmatch(val)
 	if len(m) <= 1 {
-		return val, nil
+		return val
 	}
 
-	return m[1], nil
+	return m[1]
 }
 
 func computeValueWithoutOverride(
-	jsonTargetKey map[string]any,
-	mapInfo JSONMappingInfo,
+	value any,
+	mapping *compiledMapping,
 	field *string,
 ) error {
-	switch val := jsonTargetKey[mapInfo.Key].(type) {
+	switch val := value.(type) {
 	case nil:
-		slog.Debug("key not found in json", slog.String("key", mapInfo.Key), slog.Any("json", jsonTargetKey))
+		slog.Debug("key not found in json", slog.String("key", mapping.info.Key))
 
 		return nil
 	case string:
@@ -390,48 +441,41 @@ func computeValueWithoutOverride(
 		for _, v := range val {
 			str, ok := v.(string)
 			if !ok {
-				return fmt.Errorf("error converting %s to string: %w", mapInfo.Key, ErrCantConvertToString)
+				return fmt.Errorf("error converting %s to string: %w", mapping.info.Key, ErrCantConvertToString)
 			}
 
 			values = append(values, str)
 		}
 
 		*field = strings.Join(values, " - ")
-	case any:
-		*field = fmt.Sprintf("%v", val)
 	default:
-		return fmt.Errorf("error converting %s to string: %w", mapInfo.Key, ErrCantConvertToString)
-	}
-
-	transformedVal, err := applyValueTransformerRegex(*field, mapInfo.ValueTransformerRegex)
-	if err != nil {
-		return err
+		*field = fmt.Sprintf("%v", val)
 	}
 
-	*field = transformedVal
+	*field = applyValueTransformerRegex(*field, mapping.transformer)
 
 	return nil
 }
 
 func setStringValue(
-	jsonTargetKey map[string]any,
+	value any,
 	jsonm map[string]any,
-	mapInfo JSONMappingInfo,
+	mapping *compiledMapping,
 	field *string,
 ) error {
-	*field = getDefaultStringValue(mapInfo, mapInfo.DefaultValue)
+	*field = getDefaultStringValue(mapping.info, mapping.info.DefaultValue)
 
-	if mapInfo.OverrideValue == "" {
-		err := computeValueWithoutOverride(jsonTargetKey, mapInfo, field)
+	if mapping.info.OverrideValue == "" {
+		err := computeValueWithoutOverride(value, mapping, field)
 		if err != nil {
 			return fmt.Errorf("error computing value without override: %w", err)
 		}
 	}
 
-	if mapInfo.Suffix != nil {
+	if mapping.suffix != nil {
 		var suffix string
 
-		_, err := setValue(jsonm, *mapInfo.Suffix, &suffix)
+		_, err := setValue(jsonm, mapping.suffix, &suffix)
 		if err != nil {
 			return fmt.Errorf("error setting suffix: %w", err)
 		}
@@ -442,54 +486,80 @@ func setStringValue(
 	return nil
 }
 
-func setIntValue(jsonm map[string]any, mapInfo JSONMappingInfo, field *int) error {
-	var def int
+func setIntValue(value any, mapping *compiledMapping, field *int) error {
+	*field = mapping.defaultInt
 
-	if mapInfo.DefaultValue != "" {
-		num, err := strconv.Atoi(mapInfo.DefaultValue)
-		if err != nil {
-			return fmt.Errorf(
-				"error converting default value %s to int: %w",
-				mapInfo.DefaultValue,
-				err,
-			)
-		}
-
-		def = num
-	}
-
-	*field = getDefaultIntValue(mapInfo, def)
-
-	if jsonm[mapInfo.Key] == nil {
-		return nil
-	}
-
-	value := jsonm[mapInfo.Key]
 	switch val := value.(type) {
+	case nil:
+		return nil
 	case int:
 		*field = val
 	case float64:
 		*field = int(val)
 	case string:
-		r, err := regexp.Compile(mapInfo.ValueTransformerRegex)
-		if err != nil {
-			return fmt.Errorf("error compiling regex: %w", err)
+		// Strings are only converted using a value transformer regex
+		if mapping.transformer == nil {
+			return nil
 		}
 
-		matches := r.FindStringSubmatch(val)
+		matches := mapping.transformer.FindStringSubmatch(val)
 		if len(matches) <= 1 {
 			return nil
 		}
 
 		parsedValue, err := strconv.Atoi(matches[1])
 		if err != nil {
-			return fmt.Errorf("error converting %s to int: %w", mapInfo.Key, err)
+			return fmt.Errorf("error converting %s to int: %w", mapping.info.Key, err)
 		}
 
 		*field = parsedValue
 	default:
-		return fmt.Errorf("cannot convert %s to int: %w", mapInfo.Key, ErrCantConvertToInt)
+		return fmt.Errorf("cannot convert %s to int: %w", mapping.info.Key, ErrCantConvertToInt)
 	}
 
 	return nil
 }
+
+func setStringSliceValue(value any, mapping *compiledMapping, field *[]string) error {
+	splitValues := func(value string) []string {
+		if value == "" {
+			return nil
+		}
+
+		return strings.Split(value, ",")
+	}
+
+	if mapping.info.OverrideValue != "" {
+		*field = splitValues(mapping.info.OverrideValue)
+
+		return nil
+	}
+
+	*field = splitValues(mapping.info.DefaultValue)
+
+	values, isArray := value.([]any)
+	if !isArray {
+		var str string
+
+		err := computeValueWithoutOverride(value, mapping, &str)
+		if err != nil {
+			return err
+		}
+
+		if str != "" {
+			*field = []string{str}
+		}
+
+		return nil
+	}
+
+	tags := []string{}
+
+	for _, v := range values {
+		tags = append(tags, applyValueTransformerRegex(fmt.Sprintf("%v", v), mapping.transformer))
+	}
+
+	*field = tags
+
+	return nil
+}
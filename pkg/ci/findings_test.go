@@ -0,0 +1,154 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindingsFromJSONDetails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		mappings JSONToFindingsMappings
+		want     []Finding
+	}{
+		{
+			name:   "help URI, array tags and category",
+			output: `[{"rule":"a","file":"x.go","message":"m","doc":"https://example.com/a","cwe":["CWE-89","CWE-20"],"kind":"security"}]`,
+			mappings: JSONToFindingsMappings{
+				HelpURI:  JSONMappingInfo{Key: "doc"},
+				Tags:     JSONMappingInfo{Key: "cwe"},
+				Category: JSONMappingInfo{Key: "kind"},
+			},
+			want: []Finding{
+				{
+					ToolName: "tool", RuleID: "a", Level: "warning", FilePath: "x.go", Message: "m",
+					HelpURI: "https://example.com/a", Tags: []string{"CWE-89", "CWE-20"}, Category: "security",
+				},
+			},
+		},
+		{
+			name:   "tags and category defaults",
+			output: `[{"rule":"a","file":"x.go","message":"m"}]`,
+			mappings: JSONToFindingsMappings{
+				Tags:     JSONMappingInfo{Key: "cwe", DefaultValue: "go,style"},
+				Category: JSONMappingInfo{OverrideValue: "lint"},
+			},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "a", Level: "warning", FilePath: "x.go", Message: "m", Tags: []string{"go", "style"}, Category: "lint"},
+			},
+		},
+		{
+			name:   "single replacement defaulting to finding position",
+			output: `[{"rule":"a","file":"x.go","line":3,"col":2,"endCol":5,"message":"m","replacement":"new"}]`,
+			mappings: JSONToFindingsMappings{
+				StartLine: JSONMappingInfo{Key: "line"},
+				StartCol:  JSONMappingInfo{Key: "col"},
+				EndCol:    JSONMappingInfo{Key: "endCol"},
+				Fix: JSONFixMappings{
+					Description: JSONMappingInfo{OverrideValue: "replace"},
+					Text:        JSONMappingInfo{Key: "replacement"},
+				},
+			},
+			want: []Finding{
+				{
+					ToolName: "tool", RuleID: "a", Level: "warning", FilePath: "x.go", StartLine: 3, StartCol: 2, EndCol: 5, Message: "m",
+					Fix: &Fix{
+						Description:  "replace",
+						Replacements: []Replacement{{FilePath: "x.go", StartLine: 3, EndLine: 3, StartCol: 2, EndCol: 5, Text: "new"}},
+					},
+				},
+			},
+		},
+		{
+			name:   "no replacement text",
+			output: `[{"rule":"a","file":"x.go","message":"m"}]`,
+			mappings: JSONToFindingsMappings{
+				Fix: JSONFixMappings{Text: JSONMappingInfo{Key: "replacement"}},
+			},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "a", Level: "warning", FilePath: "x.go", Message: "m"},
+			},
+		},
+		{
+			name: "array of replacements",
+			output: `[{"rule":"a","file":"x.go","message":"m","edits":[
+				{"path":"y.go","line":1,"col":1,"endCol":3,"text":""},
+				{"line":4,"col":1,"endCol":1,"text":"inserted"}
+			]}]`,
+			mappings: JSONToFindingsMappings{
+				Fix: JSONFixMappings{
+					BaseArrayKey: "edits",
+					FilePath:     JSONMappingInfo{Key: "path"},
+					StartLine:    JSONMappingInfo{Key: "line"},
+					StartCol:     JSONMappingInfo{Key: "col"},
+					EndCol:       JSONMappingInfo{Key: "endCol"},
+					Text:         JSONMappingInfo{Key: "text"},
+				},
+			},
+			want: []Finding{
+				{
+					ToolName: "tool", RuleID: "a", Level: "warning", FilePath: "x.go", Message: "m",
+					Fix: &Fix{Replacements: []Replacement{
+						{FilePath: "y.go", StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 3, Text: ""},
+						{FilePath: "x.go", StartLine: 4, EndLine: 4, StartCol: 1, EndCol: 1, Text: "inserted"},
+					}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mappings := test.mappings
+			base := fullMappings()
+			mappings.ToolName = base.ToolName
+			mappings.RuleID = base.RuleID
+			mappings.Level = base.Level
+			mappings.FilePath = base.FilePath
+			mappings.Message = base.Message
+
+			got, err := FindingsFromJSON(test.output, JSONInfos{Mappings: mappings})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("FindingsFromJSON() =\n%+v\nwant\n%+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindingDetails(t *testing.T) {
+	t.Parallel()
+
+	finding := Finding{
+		Message:  "m",
+		HelpURI:  "https://example.com/a",
+		Tags:     []string{"CWE-89", "owasp"},
+		Category: "security",
+		Fix:      &Fix{Description: "quote", Replacements: []Replacement{{}, {}}},
+	}
+
+	want := []string{"Category: security", "Tags: CWE-89, owasp", "Help: https://example.com/a", "Suggested fix: quote, 2 replacement(s)"}
+	if got := findingDetails(&finding); !reflect.DeepEqual(got, want) {
+		t.Errorf("findingDetails() = %q, want %q", got, want)
+	}
+
+	if got := messageWithHelp(&finding); got != "m - See https://example.com/a" {
+		t.Errorf("messageWithHelp() = %q", got)
+	}
+
+	finding.Message = "see https://example.com/a"
+	if got := messageWithHelp(&finding); got != finding.Message {
+		t.Errorf("messageWithHelp() = %q, want message not to repeat help URI", got)
+	}
+}
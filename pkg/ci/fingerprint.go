@@ -0,0 +1,92 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Cache of files lines, so that files with many findings are read once
+type fileLinesCache map[string][]string
+
+func (c fileLinesCache) get(path string) []string {
+	lines, ok := c[path]
+	if ok {
+		return lines
+	}
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		lines = strings.Split(string(content), "\n")
+	}
+
+	c[path] = lines
+
+	return lines
+}
+
+// Code the finding points to, with whitespaces trimmed so that reindenting does not change it. Falls back to the
+// message for findings that are not tied to lines of an existing file.
+func fingerprintContext(f *Finding, path string, cache fileLinesCache) string {
+	if f.StartLine <= 0 {
+		return f.Message
+	}
+
+	lines := cache.get(path)
+	endLine := max(f.EndLine, f.StartLine)
+
+	if endLine > len(lines) {
+		return f.Message
+	}
+
+	snippet := make([]string, 0, endLine-f.StartLine+1)
+	for _, line := range lines[f.StartLine-1 : endLine] {
+		snippet = append(snippet, strings.Join(strings.Fields(line), " "))
+	}
+
+	return strings.Join(snippet, "\n")
+}
+
+func normalizeFingerprintPath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+// Compute findings fingerprints, based on tool, rule, path and code the finding points to rather than line numbers, so
+// that they are stable when unrelated lines change. Identical findings in the same file are told apart by their
+// occurrence index. Findings that already have a fingerprint are left untouched.
+func computeFingerprints(findings []*Finding) {
+	cache := fileLinesCache{}
+	seen := map[string]int{}
+
+	for _, finding := range findings {
+		if finding.Fingerprint != "" {
+			continue
+		}
+
+		path := normalizeFingerprintPath(finding.FilePath)
+
+		hash := sha256.Sum256([]byte(strings.Join([]string{
+			finding.ToolName,
+			finding.RuleID,
+			path,
+			fingerprintContext(finding, path, cache),
+		}, "\x00")))
+		fingerprint := hex.EncodeToString(hash[:])
+
+		occurrence := seen[fingerprint]
+		seen[fingerprint]++
+
+		if occurrence > 0 {
+			hash = sha256.Sum256([]byte(fingerprint + "\x00" + strconv.Itoa(occurrence)))
+			fingerprint = hex.EncodeToString(hash[:])
+		}
+
+		finding.Fingerprint = fingerprint
+	}
+}
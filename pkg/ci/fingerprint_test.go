@@ -0,0 +1,67 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeFingerprintsStableWhenLinesShift(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "main.go")
+
+	fingerprint := func(content string, line int) string {
+		t.Helper()
+
+		err := os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		finding := Finding{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: path, StartLine: line, Message: "message"}
+		computeFingerprints([]*Finding{&finding})
+
+		return finding.Fingerprint
+	}
+
+	original := fingerprint("package main\n\nfunc main() {\n\tpanic(nil)\n}\n", 4)
+
+	shifted := fingerprint("// Package main is a program\npackage main\n\nimport \"os\"\n\nfunc main() {\n\tpanic(nil)\n}\n", 7)
+	if shifted != original {
+		t.Error("fingerprint changed when lines above the finding were added")
+	}
+
+	reindented := fingerprint("package main\n\nfunc main() {\n        panic(nil)\n}\n", 4)
+	if reindented != original {
+		t.Error("fingerprint changed when the finding line was reindented")
+	}
+
+	changed := fingerprint("package main\n\nfunc main() {\n\tpanic(\"error\")\n}\n", 4)
+	if changed == original {
+		t.Error("fingerprint did not change when the finding line changed")
+	}
+}
+
+func TestComputeFingerprintsOccurrences(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		{ToolName: "tool", RuleID: "rule", FilePath: "missing.go", Message: "message"},
+		{ToolName: "tool", RuleID: "rule", FilePath: "./missing.go", Message: "message"},
+		{ToolName: "tool", RuleID: "rule", FilePath: "missing.go", Message: "message", Fingerprint: "kept"},
+	}
+
+	computeFingerprints([]*Finding{&findings[0], &findings[1], &findings[2]})
+
+	if findings[0].Fingerprint == "" || findings[0].Fingerprint == findings[1].Fingerprint {
+		t.Errorf("identical findings fingerprints = %q and %q, want distinct ones", findings[0].Fingerprint, findings[1].Fingerprint)
+	}
+
+	if findings[2].Fingerprint != "kept" {
+		t.Errorf("existing fingerprint = %q, want it untouched", findings[2].Fingerprint)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// See https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+	Categories  []string            `json:"categories,omitempty"`
+	Content     *codeQualityContent `json:"content,omitempty"`
+}
+
+type codeQualityContent struct {
+	Body string `json:"body"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// Map normalized finding levels (see validateFindings) to Code Quality severities
+func codeQualitySeverity(level string) string {
+	switch level {
+	case "error":
+		return "critical"
+	case "warning":
+		return "major"
+	case "notice":
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// Map finding categories to Code Quality ones, see https://github.com/codeclimate/platform/blob/master/spec/analyzers/SPEC.md#categories
+func codeQualityCategories(category string) []string {
+	switch category {
+	case "":
+		return nil
+	case "security", "secret", "vulnerability":
+		return []string{"Security"}
+	case "lint":
+		return []string{"Style"}
+	case "dependency":
+		return []string{"Compatibility"}
+	default:
+		return []string{"Bug Risk"}
+	}
+}
+
+// Markdown body of an issue, giving details that do not fit in its description
+func codeQualityContentFromFinding(f *Finding) *codeQualityContent {
+	details := findingDetails(f)
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &codeQualityContent{Body: strings.Join(details, "\n\n")}
+}
+
+func codeQualityFromFindings(findings []*Finding) []codeQualityIssue {
+	issues := []codeQualityIssue{}
+
+	for _, finding := range findings {
+		lines := codeQualityLines{
+			// Lines are mandatory, findings not tied to a line are reported on the first one
+			Begin: max(finding.StartLine, 1),
+			End:   0,
+		}
+
+		if finding.EndLine > lines.Begin {
+			lines.End = finding.EndLine
+		}
+
+		issues = append(issues, codeQualityIssue{
+			Description: finding.Message,
+			CheckName:   finding.ToolName + "/" + finding.RuleID,
+			Fingerprint: finding.Fingerprint,
+			Severity:    codeQualitySeverity(finding.Level),
+			Location: codeQualityLocation{
+				Path:  finding.FilePath,
+				Lines: lines,
+			},
+			Categories: codeQualityCategories(finding.Category),
+			Content:    codeQualityContentFromFinding(finding),
+		})
+	}
+
+	return issues
+}
+
+func printFindingsCodeQuality(w io.Writer, findings []*Finding) error {
+	output, err := json.MarshalIndent(codeQualityFromFindings(findings), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling findings to Code Quality report: %w", err)
+	}
+
+	fmt.Fprintln(w, string(output))
+
+	return nil
+}
+
+// Print human-readable findings in collapsible log sections, one per tool, see
+// https://docs.gitlab.com/ci/jobs/job_logs/#custom-collapsible-sections
+func printFindingsGitlab(w io.Writer, findings []*Finding) {
+	sectionNameSanitizer := regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+	var toolOrder []string
+
+	byTool := map[string][]*Finding{}
+
+	for _, finding := range findings {
+		if _, ok := byTool[finding.ToolName]; !ok {
+			toolOrder = append(toolOrder, finding.ToolName)
+		}
+
+		byTool[finding.ToolName] = append(byTool[finding.ToolName], finding)
+	}
+
+	for _, tool := range toolOrder {
+		sectionName := "kema_runner_" + sectionNameSanitizer.ReplaceAllString(tool, "_")
+
+		fmt.Fprintf(
+			w,
+			"\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s: %d finding(s)\n",
+			time.Now().Unix(),
+			sectionName,
+			tool,
+			len(byTool[tool]),
+		)
+		printFindingsHuman(w, byTool[tool])
+		fmt.Fprintf(w, "\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", time.Now().Unix(), sectionName)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCodeQualityFromFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{
+			ToolName:    "semgrep",
+			RuleID:      "sql",
+			Level:       "error",
+			FilePath:    "db.go",
+			StartLine:   3,
+			EndLine:     5,
+			Message:     "sql injection",
+			Fingerprint: "fp",
+			HelpURI:     "https://example.com/sql",
+			Category:    "security",
+		},
+		{ToolName: "hadolint", RuleID: "DL3008", Level: "notice", FilePath: "Dockerfile", StartLine: 2, EndLine: 2, Message: "pin versions", Category: "lint"},
+		{ToolName: "grype", RuleID: "CVE-2025-0001", Level: "debug", FilePath: "go.mod", Message: "vulnerable"},
+	}
+
+	want := []codeQualityIssue{
+		{
+			Description: "sql injection",
+			CheckName:   "semgrep/sql",
+			Fingerprint: "fp",
+			Severity:    "critical",
+			Location:    codeQualityLocation{Path: "db.go", Lines: codeQualityLines{Begin: 3, End: 5}},
+			Categories:  []string{"Security"},
+			Content:     &codeQualityContent{Body: "Category: security\n\nHelp: https://example.com/sql"},
+		},
+		{
+			Description: "pin versions",
+			CheckName:   "hadolint/DL3008",
+			Severity:    "minor",
+			Location:    codeQualityLocation{Path: "Dockerfile", Lines: codeQualityLines{Begin: 2}},
+			Categories:  []string{"Style"},
+			Content:     &codeQualityContent{Body: "Category: lint"},
+		},
+		{
+			Description: "vulnerable",
+			CheckName:   "grype/CVE-2025-0001",
+			Severity:    "info",
+			// Lines are mandatory
+			Location: codeQualityLocation{Path: "go.mod", Lines: codeQualityLines{Begin: 1}},
+		},
+	}
+
+	got := codeQualityFromFindings(findings)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("codeQualityFromFindings() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestPrintFindingsGitlab(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{ToolName: "sast-semgrep", RuleID: "a", Level: "error", FilePath: "a.go", Message: "first"},
+		{ToolName: "go lint", RuleID: "b", Level: "warning", FilePath: "b.go", Message: "second"},
+		{ToolName: "sast-semgrep", RuleID: "c", Level: "error", FilePath: "c.go", Message: "third"},
+	}
+
+	var builder strings.Builder
+
+	printFindingsGitlab(&builder, findings)
+
+	// Sections are collapsed, one per tool in order of appearance, with names GitLab accepts
+	starts := regexp.MustCompile(`section_start:\d+:(\S+)\[collapsed=true\]\r\x1b\[0K(.+)\n`).FindAllStringSubmatch(builder.String(), -1)
+
+	got := []string{}
+	for _, start := range starts {
+		got = append(got, start[1]+" "+start[2])
+	}
+
+	want := []string{"kema_runner_sast-semgrep sast-semgrep: 2 finding(s)", "kema_runner_go_lint go lint: 1 finding(s)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sections = %q, want %q", got, want)
+	}
+
+	if count := strings.Count(builder.String(), "section_end:"); count != len(want) {
+		t.Errorf("got %d section ends, want %d", count, len(want))
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"slices"
+	"time"
+
+	"github.com/kemadev/ci-cd/web"
+)
+
+const (
+	htmlReportTemplate = "tmpl/report.gotmpl.html"
+	htmlReportStyle    = "static/report.css"
+	htmlReportScript   = "static/report.js"
+)
+
+type htmlReportData struct {
+	GeneratedAt string
+	Total       int
+	Levels      []string
+	Tools       []htmlReportTool
+	// Assets are inlined so that the report is a single self-contained file
+	Style  template.CSS
+	Script template.JS
+}
+
+type htmlReportTool struct {
+	Name string
+	// Count of findings per level, in the same order as htmlReportData.Levels
+	Counts []int
+	Total  int
+	Files  []htmlReportFile
+}
+
+type htmlReportFile struct {
+	Path     string
+	Findings []*Finding
+}
+
+func levelRank(level string) int {
+	rank := slices.Index(findingLevels(), level)
+	if rank == -1 {
+		return len(findingLevels())
+	}
+
+	return rank
+}
+
+func htmlReportFromFindings(findings []*Finding) htmlReportData {
+	data := htmlReportData{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Total:       len(findings),
+		Levels:      findingLevels(),
+		Tools:       []htmlReportTool{},
+		Style:       "",
+		Script:      "",
+	}
+
+	toolIndexes := map[string]int{}
+	fileIndexes := map[string]map[string]int{}
+
+	for _, finding := range findings {
+		toolIndex, ok := toolIndexes[finding.ToolName]
+		if !ok {
+			toolIndex = len(data.Tools)
+			toolIndexes[finding.ToolName] = toolIndex
+			fileIndexes[finding.ToolName] = map[string]int{}
+
+			data.Tools = append(data.Tools, htmlReportTool{
+				Name:   finding.ToolName,
+				Counts: make([]int, len(data.Levels)),
+				Total:  0,
+				Files:  []htmlReportFile{},
+			})
+		}
+
+		tool := &data.Tools[toolIndex]
+		tool.Total++
+
+		if rank := levelRank(finding.Level); rank < len(tool.Counts) {
+			tool.Counts[rank]++
+		}
+
+		fileIndex, ok := fileIndexes[finding.ToolName][finding.FilePath]
+		if !ok {
+			fileIndex = len(tool.Files)
+			fileIndexes[finding.ToolName][finding.FilePath] = fileIndex
+
+			tool.Files = append(tool.Files, htmlReportFile{
+				Path:     finding.FilePath,
+				Findings: []*Finding{},
+			})
+		}
+
+		tool.Files[fileIndex].Findings = append(tool.Files[fileIndex].Findings, finding)
+	}
+
+	for _, tool := range data.Tools {
+		slices.SortFunc(tool.Files, func(a, b htmlReportFile) int {
+			return cmp.Compare(a.Path, b.Path)
+		})
+
+		for _, file := range tool.Files {
+			slices.SortStableFunc(file.Findings, func(a, b *Finding) int {
+				return cmp.Or(
+					cmp.Compare(levelRank(a.Level), levelRank(b.Level)),
+					cmp.Compare(a.StartLine, b.StartLine),
+				)
+			})
+		}
+	}
+
+	return data
+}
+
+func printFindingsHTML(w io.Writer, findings []*Finding) error {
+	tmpl, err := template.ParseFS(web.GetTmplFS(), htmlReportTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing HTML report template: %w", err)
+	}
+
+	style, err := fs.ReadFile(web.GetStaticFS(), htmlReportStyle)
+	if err != nil {
+		return fmt.Errorf("error reading HTML report style: %w", err)
+	}
+
+	script, err := fs.ReadFile(web.GetStaticFS(), htmlReportScript)
+	if err != nil {
+		return fmt.Errorf("error reading HTML report script: %w", err)
+	}
+
+	data := htmlReportFromFindings(findings)
+	//nolint:gosec // assets are embedded at build time, they are not user controlled
+	data.Style = template.CSS(style)
+	//nolint:gosec // same
+	data.Script = template.JS(script)
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		return fmt.Errorf("error rendering HTML report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTMLReportFromFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{ToolName: "semgrep", RuleID: "a", Level: "warning", FilePath: "b.go", StartLine: 9, Message: "m"},
+		{ToolName: "semgrep", RuleID: "b", Level: "error", FilePath: "b.go", StartLine: 12, Message: "m"},
+		{ToolName: "hadolint", RuleID: "c", Level: "notice", FilePath: "Dockerfile", StartLine: 1, Message: "m"},
+		{ToolName: "semgrep", RuleID: "c", Level: "warning", FilePath: "a.go", StartLine: 3, Message: "m"},
+		{ToolName: "semgrep", RuleID: "d", Level: "warning", FilePath: "b.go", StartLine: 2, Message: "m"},
+	}
+
+	data := htmlReportFromFindings(findings)
+
+	if data.Total != len(findings) {
+		t.Errorf("total = %d, want %d", data.Total, len(findings))
+	}
+
+	if len(data.Tools) != 2 || data.Tools[0].Name != "semgrep" || data.Tools[1].Name != "hadolint" {
+		t.Fatalf("tools = %+v, want semgrep then hadolint", data.Tools)
+	}
+
+	semgrep := data.Tools[0]
+
+	if want := []int{1, 3, 0, 0}; !reflect.DeepEqual(semgrep.Counts, want) || semgrep.Total != 4 {
+		t.Errorf("semgrep counts = %v and total = %d, want %v and 4", semgrep.Counts, semgrep.Total, want)
+	}
+
+	// Files are sorted by path, findings by level then line
+	got := []string{}
+
+	for _, file := range semgrep.Files {
+		for _, finding := range file.Findings {
+			got = append(got, file.Path+"/"+finding.RuleID)
+		}
+	}
+
+	if want := []string{"a.go/c", "b.go/b", "b.go/d", "b.go/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findings order = %v, want %v", got, want)
+	}
+}
+
+func TestPrintFindingsHTML(t *testing.T) {
+	t.Parallel()
+
+	var builder strings.Builder
+
+	err := printFindingsHTML(&builder, []*Finding{
+		{ToolName: "semgrep", RuleID: "xss", Level: "error", FilePath: "a.go", StartLine: 3, StartCol: 2, Message: "<script>alert(1)</script>", HelpURI: "https://example.com/xss"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := builder.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		`<a href="https://example.com/xss"><code>xss</code></a>`,
+		"<td>3:2</td>",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("report does not contain %q", want)
+		}
+	}
+
+	// Report is self-contained
+	if strings.Contains(output, "<link") || strings.Contains(output, "<script src") {
+		t.Error("report references external assets")
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const junitTimestampFormat = "2006-01-02T15:04:05"
+
+// See https://github.com/testmoapp/junitxml for a description of the de-facto standard format
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr,omitempty"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+	SystemOut string          `xml:"system-out,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// Event emitted by `go test -json`, see `go doc test2json`
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+func junitDuration(seconds float64) string {
+	//nolint:mnd // milliseconds precision
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+func (s *junitTestSuites) computeTotals() {
+	s.Tests = 0
+	s.Failures = 0
+	s.Skipped = 0
+
+	for i := range s.Suites {
+		suite := &s.Suites[i]
+		suite.Tests = len(suite.TestCases)
+		suite.Failures = 0
+		suite.Skipped = 0
+
+		for _, testCase := range suite.TestCases {
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			}
+		}
+
+		s.Tests += suite.Tests
+		s.Failures += suite.Failures
+		s.Skipped += suite.Skipped
+	}
+}
+
+func junitFromFindings(findings []*Finding) junitTestSuites {
+	report := junitTestSuites{
+		XMLName:  xml.Name{Space: "", Local: ""},
+		Tests:    0,
+		Failures: 0,
+		Skipped:  0,
+		Time:     "",
+		Suites:   []junitTestSuite{},
+	}
+
+	suiteIndexes := map[string]int{}
+
+	for _, finding := range findings {
+		suiteIndex, ok := suiteIndexes[finding.ToolName]
+		if !ok {
+			suiteIndex = len(report.Suites)
+			suiteIndexes[finding.ToolName] = suiteIndex
+
+			report.Suites = append(report.Suites, junitTestSuite{
+				Name:      finding.ToolName,
+				Tests:     0,
+				Failures:  0,
+				Skipped:   0,
+				Time:      "",
+				Timestamp: "",
+				TestCases: []junitTestCase{},
+				SystemOut: "",
+			})
+		}
+
+		location := finding.FilePath
+		if finding.StartLine > 0 {
+			location += ":" + strconv.Itoa(finding.StartLine)
+		}
+
+		if finding.StartCol > 0 {
+			location += ":" + strconv.Itoa(finding.StartCol)
+		}
+
+		report.Suites[suiteIndex].TestCases = append(
+			report.Suites[suiteIndex].TestCases,
+			junitTestCase{
+				Name:      finding.RuleID + " " + location,
+				Classname: finding.ToolName,
+				File:      finding.FilePath,
+				Line:      finding.StartLine,
+				Time:      "",
+				Failure: &junitFailure{
+					Message: finding.Message,
+					Type:    finding.Level,
+					Text:    strings.Join(append([]string{location + ": " + finding.Message}, findingDetails(finding)...), "\n"),
+				},
+				Skipped:   nil,
+				SystemOut: "",
+			},
+		)
+	}
+
+	report.computeTotals()
+
+	return report
+}
+
+type goTestCaseState struct {
+	testCase junitTestCase
+	output   strings.Builder
+	done     bool
+}
+
+type goTestSuiteState struct {
+	suite     junitTestSuite
+	output    strings.Builder
+	failed    bool
+	elapsed   float64
+	testOrder []string
+	tests     map[string]*goTestCaseState
+}
+
+func (s *goTestSuiteState) getTest(name string) *goTestCaseState {
+	test, ok := s.tests[name]
+	if !ok {
+		test = &goTestCaseState{
+			testCase: junitTestCase{
+				Name:      name,
+				Classname: s.suite.Name,
+				File:      "",
+				Line:      0,
+				Time:      "",
+				Failure:   nil,
+				Skipped:   nil,
+				SystemOut: "",
+			},
+			output: strings.Builder{},
+			done:   false,
+		}
+		s.tests[name] = test
+		s.testOrder = append(s.testOrder, name)
+	}
+
+	return test
+}
+
+func (s *goTestSuiteState) handleEvent(event goTestEvent) {
+	if s.suite.Timestamp == "" && !event.Time.IsZero() {
+		s.suite.Timestamp = event.Time.Format(junitTimestampFormat)
+	}
+
+	if event.Test == "" {
+		switch event.Action {
+		case "output":
+			s.output.WriteString(event.Output)
+		case "fail":
+			s.failed = true
+			s.elapsed = event.Elapsed
+		case "pass", "skip":
+			s.elapsed = event.Elapsed
+		}
+
+		return
+	}
+
+	test := s.getTest(event.Test)
+
+	switch event.Action {
+	case "output":
+		test.output.WriteString(event.Output)
+	case "pass":
+		test.done = true
+		test.testCase.Time = junitDuration(event.Elapsed)
+	case "fail":
+		test.done = true
+		test.testCase.Time = junitDuration(event.Elapsed)
+		test.testCase.Failure = &junitFailure{
+			Message: "Failed",
+			Type:    "",
+			Text:    "",
+		}
+	case "skip":
+		test.done = true
+		test.testCase.Time = junitDuration(event.Elapsed)
+		test.testCase.Skipped = &junitSkipped{Message: "Skipped"}
+	}
+}
+
+func (s *goTestSuiteState) toSuite() junitTestSuite {
+	suite := s.suite
+	suite.Time = junitDuration(s.elapsed)
+	suite.SystemOut = s.output.String()
+
+	hasFailedTest := false
+
+	for _, name := range s.testOrder {
+		test := s.tests[name]
+		testCase := test.testCase
+		output := test.output.String()
+
+		switch {
+		case testCase.Failure != nil:
+			hasFailedTest = true
+			testCase.Failure.Text = output
+		case testCase.Skipped != nil:
+			testCase.Skipped.Message = skipReason(output)
+			testCase.SystemOut = output
+		case !test.done:
+			// Test was interrupted, e.g. by a panic or a timeout of the test binary
+			hasFailedTest = true
+			testCase.Failure = &junitFailure{
+				Message: "Test did not complete",
+				Type:    "",
+				Text:    output,
+			}
+		default:
+			testCase.SystemOut = output
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// Package failed without any failing test, most likely a build failure
+	if s.failed && !hasFailedTest {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "[package]",
+			Classname: suite.Name,
+			File:      "",
+			Line:      0,
+			Time:      junitDuration(s.elapsed),
+			Failure: &junitFailure{
+				Message: "Package failed",
+				Type:    "",
+				Text:    suite.SystemOut,
+			},
+			Skipped:   nil,
+			SystemOut: "",
+		})
+	}
+
+	return suite
+}
+
+// Last line logged by the test that is not a test2json framing line, usually the argument of `t.Skip`
+func skipReason(output string) string {
+	reason := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=== ") || strings.HasPrefix(line, "--- ") {
+			continue
+		}
+
+		reason = line
+	}
+
+	return reason
+}
+
+// GoTestJunit builds JUnit XML test suites from a `go test -json` output stream, as it is written.
+type GoTestJunit struct {
+	// Tool name of findings parsed from the same stream, which test suites supersede in JUnit reports
+	toolName string
+	// Partial line, completed by next writes
+	pending    []byte
+	suiteOrder []string
+	suites     map[string]*goTestSuiteState
+}
+
+func NewGoTestJunit(toolName string) *GoTestJunit {
+	return &GoTestJunit{
+		toolName:   toolName,
+		pending:    nil,
+		suiteOrder: []string{},
+		suites:     map[string]*goTestSuiteState{},
+	}
+}
+
+// Write handles events of complete lines, keeping the last partial one for next writes.
+func (j *GoTestJunit) Write(p []byte) (int, error) {
+	j.pending = append(j.pending, p...)
+
+	for {
+		i := bytes.IndexByte(j.pending, '\n')
+		if i == -1 {
+			break
+		}
+
+		j.handleLine(j.pending[:i])
+		j.pending = j.pending[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (j *GoTestJunit) handleLine(line []byte) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+
+	var event goTestEvent
+
+	// Non-JSON lines, like build errors printed by the go command, are already reflected in the package status
+	err := json.Unmarshal(line, &event)
+	if err != nil || event.Package == "" {
+		return
+	}
+
+	suite, ok := j.suites[event.Package]
+	if !ok {
+		suite = &goTestSuiteState{
+			suite: junitTestSuite{
+				Name:      event.Package,
+				Tests:     0,
+				Failures:  0,
+				Skipped:   0,
+				Time:      "",
+				Timestamp: "",
+				TestCases: []junitTestCase{},
+				SystemOut: "",
+			},
+			output:    strings.Builder{},
+			failed:    false,
+			elapsed:   0,
+			testOrder: []string{},
+			tests:     map[string]*goTestCaseState{},
+		}
+		j.suites[event.Package] = suite
+		j.suiteOrder = append(j.suiteOrder, event.Package)
+	}
+
+	suite.handleEvent(event)
+}
+
+// Test suites built so far, one per package, along with their total duration in seconds.
+func (j *GoTestJunit) testSuites() ([]junitTestSuite, float64) {
+	// Stream may not end with a line break
+	j.handleLine(j.pending)
+	j.pending = nil
+
+	var totalTime float64
+
+	suites := []junitTestSuite{}
+
+	for _, name := range j.suiteOrder {
+		totalTime += j.suites[name].elapsed
+		suites = append(suites, j.suites[name].toSuite())
+	}
+
+	return suites, totalTime
+}
+
+func printJunit(w io.Writer, report junitTestSuites) error {
+	output, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling JUnit report: %w", err)
+	}
+
+	fmt.Fprintln(w, xml.Header+string(output))
+
+	return nil
+}
+
+// Print a single JUnit XML report, holding test suites of go test runs, followed by a test suite per tool reporting
+// findings.
+func printFindingsJunit(w io.Writer, findings []*Finding, goTests []*GoTestJunit) error {
+	var (
+		suites    []junitTestSuite
+		totalTime float64
+		testTools []string
+	)
+
+	for _, goTest := range goTests {
+		testSuites, elapsed := goTest.testSuites()
+		suites = append(suites, testSuites...)
+		totalTime += elapsed
+		testTools = append(testTools, goTest.toolName)
+	}
+
+	// Findings of go test runs are failed test cases of their test suites already
+	report := junitFromFindings(slices.DeleteFunc(slices.Clone(findings), func(f *Finding) bool {
+		return slices.Contains(testTools, f.ToolName)
+	}))
+
+	if len(goTests) > 0 {
+		report.Suites = append(suites, report.Suites...)
+		report.Time = junitDuration(totalTime)
+		report.computeTotals()
+	}
+
+	return printJunit(w, report)
+}
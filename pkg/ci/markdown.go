@@ -0,0 +1,184 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Levels in descending order of importance, as normalized by validateFindings
+func findingLevels() []string {
+	return []string{"error", "warning", "notice", "debug"}
+}
+
+// Base URL to browse files at the current commit, empty if not running in GitHub Actions
+func githubBlobBaseURL() string {
+	server := os.Getenv("GITHUB_SERVER_URL")
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	sha := os.Getenv("GITHUB_SHA")
+
+	if server == "" || repo == "" || sha == "" {
+		return ""
+	}
+
+	return server + "/" + repo + "/blob/" + sha + "/"
+}
+
+func markdownLocation(f *Finding, blobBaseURL string) string {
+	location := f.FilePath
+	anchor := ""
+
+	if f.StartLine > 0 {
+		location += ":" + strconv.Itoa(f.StartLine)
+		anchor = "#L" + strconv.Itoa(f.StartLine)
+
+		if f.EndLine > f.StartLine {
+			anchor += "-L" + strconv.Itoa(f.EndLine)
+		}
+	}
+
+	location = "<code>" + html.EscapeString(location) + "</code>"
+
+	// Repository-level findings (PR title, stale branches, ...) do not point to an actual file
+	_, err := os.Stat(f.FilePath)
+	if blobBaseURL == "" || err != nil {
+		return location
+	}
+
+	return "<a href=\"" + html.EscapeString(blobBaseURL+f.FilePath+anchor) + "\">" + location + "</a>"
+}
+
+func markdownText(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+// Rule ID of the finding, linking to its documentation if any
+func markdownRule(f *Finding) string {
+	rule := "<code>" + html.EscapeString(f.RuleID) + "</code>"
+	if f.HelpURI == "" {
+		return rule
+	}
+
+	return "<a href=\"" + html.EscapeString(f.HelpURI) + "\">" + rule + "</a>"
+}
+
+// Message of the finding, followed by its category, tags and suggested fix if any
+func markdownMessage(f *Finding) string {
+	var details []string
+
+	if f.Category != "" {
+		details = append(details, f.Category)
+	}
+
+	details = append(details, f.Tags...)
+
+	if f.Fix != nil {
+		details = append(details, "fix available")
+	}
+
+	if len(details) == 0 {
+		return markdownText(f.Message)
+	}
+
+	return markdownText(f.Message) + " <sub>" + markdownText(strings.Join(details, " · ")) + "</sub>"
+}
+
+func printFindingsMarkdown(w io.Writer, findings []*Finding) {
+	fmt.Fprintln(w, "## Findings")
+	fmt.Fprintln(w)
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No finding found :tada:")
+		fmt.Fprintln(w)
+
+		return
+	}
+
+	var toolOrder []string
+
+	byTool := map[string][]*Finding{}
+
+	for _, finding := range findings {
+		if _, ok := byTool[finding.ToolName]; !ok {
+			toolOrder = append(toolOrder, finding.ToolName)
+		}
+
+		byTool[finding.ToolName] = append(byTool[finding.ToolName], finding)
+	}
+
+	fmt.Fprintln(w, "| Tool | "+strings.Join(findingLevels(), " | ")+" | Total |")
+	fmt.Fprintln(w, "| --- |"+strings.Repeat(" ---: |", len(findingLevels())+1))
+
+	for _, tool := range toolOrder {
+		row := "| " + markdownText(tool) + " |"
+
+		for _, level := range findingLevels() {
+			count := 0
+
+			for _, finding := range byTool[tool] {
+				if finding.Level == level {
+					count++
+				}
+			}
+
+			row += " " + strconv.Itoa(count) + " |"
+		}
+
+		fmt.Fprintln(w, row+" "+strconv.Itoa(len(byTool[tool]))+" |")
+	}
+
+	fmt.Fprintln(w)
+
+	blobBaseURL := githubBlobBaseURL()
+
+	for _, tool := range toolOrder {
+		fmt.Fprintf(w, "<details><summary>%s (%d)</summary>\n\n", markdownText(tool), len(byTool[tool]))
+
+		for _, finding := range byTool[tool] {
+			fmt.Fprintf(
+				w,
+				"- **%s** %s %s: %s\n",
+				finding.Level,
+				markdownLocation(finding, blobBaseURL),
+				markdownRule(finding),
+				markdownMessage(finding),
+			)
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "</details>")
+		fmt.Fprintln(w)
+	}
+}
+
+// Write a collapsible Markdown list of findings that were suppressed, so that they remain visible to reviewers.
+func WriteSuppressedFindingsMarkdown(w io.Writer, findings []Finding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	blobBaseURL := githubBlobBaseURL()
+
+	fmt.Fprintf(w, "<details><summary>Suppressed findings (%d)</summary>\n\n", len(findings))
+
+	for i := range findings {
+		fmt.Fprintf(
+			w,
+			"- %s %s <code>%s</code>: %s\n",
+			markdownText(findings[i].ToolName),
+			markdownLocation(&findings[i], blobBaseURL),
+			html.EscapeString(findings[i].RuleID),
+			markdownText(findings[i].Message),
+		)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "</details>")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintFindingsMarkdown(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{ToolName: "semgrep", RuleID: "sql", Level: "error", FilePath: "db.go", StartLine: 3, EndLine: 5, Message: "sql <injection>", HelpURI: "https://example.com/sql", Category: "security"},
+		{ToolName: "hadolint", RuleID: "DL3008", Level: "notice", FilePath: "Dockerfile", Message: "pin versions", Fix: &Fix{}},
+		{ToolName: "semgrep", RuleID: "exec", Level: "warning", FilePath: "exec.go", StartLine: 1, Message: "first line\nsecond line"},
+	}
+
+	var builder strings.Builder
+
+	printFindingsMarkdown(&builder, findings)
+
+	output := builder.String()
+
+	for _, want := range []string{
+		"| Tool | error | warning | notice | debug | Total |\n",
+		"| semgrep | 1 | 1 | 0 | 0 | 2 |\n",
+		"| hadolint | 0 | 0 | 1 | 0 | 1 |\n",
+		"<details><summary>semgrep (2)</summary>\n",
+		"- **error** <code>db.go:3</code> <a href=\"https://example.com/sql\"><code>sql</code></a>: sql &lt;injection&gt; <sub>security</sub>\n",
+		"- **notice** <code>Dockerfile</code> <code>DL3008</code>: pin versions <sub>fix available</sub>\n",
+		"first line<br>second line",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+
+	if strings.Index(output, "semgrep (2)") > strings.Index(output, "hadolint (1)") {
+		t.Errorf("tools are not in order of appearance:\n%s", output)
+	}
+}
+
+func TestPrintFindingsMarkdownNoFinding(t *testing.T) {
+	t.Parallel()
+
+	var builder strings.Builder
+
+	printFindingsMarkdown(&builder, nil)
+
+	if want := "## Findings\n\nNo finding found :tada:\n\n"; builder.String() != want {
+		t.Errorf("output = %q, want %q", builder.String(), want)
+	}
+}
+
+func TestMarkdownLocation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "main.go")
+
+	err := os.WriteFile(path, []byte("package main\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		finding     Finding
+		blobBaseURL string
+		want        string
+	}{
+		{
+			name:        "existing file",
+			finding:     Finding{FilePath: path, StartLine: 2, EndLine: 4},
+			blobBaseURL: "https://github.com/org/repo/blob/sha/",
+			want:        "<a href=\"https://github.com/org/repo/blob/sha/" + path + "#L2-L4\"><code>" + path + ":2</code></a>",
+		},
+		{
+			name:        "outside of github actions",
+			finding:     Finding{FilePath: path, StartLine: 2},
+			blobBaseURL: "",
+			want:        "<code>" + path + ":2</code>",
+		},
+		{
+			name:        "repository-level finding",
+			finding:     Finding{FilePath: "PR title"},
+			blobBaseURL: "https://github.com/org/repo/blob/sha/",
+			want:        "<code>PR title</code>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := markdownLocation(&test.finding, test.blobBaseURL)
+			if got != test.want {
+				t.Errorf("markdownLocation() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWriteSuppressedFindingsMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var builder strings.Builder
+
+	WriteSuppressedFindingsMarkdown(&builder, nil)
+
+	if builder.String() != "" {
+		t.Errorf("output without suppressed finding = %q, want none", builder.String())
+	}
+
+	WriteSuppressedFindingsMarkdown(&builder, []Finding{
+		{ToolName: "grype", RuleID: "CVE-2025-0001", Level: "error", FilePath: "go.mod", Message: "vulnerable"},
+	})
+
+	want := "<details><summary>Suppressed findings (1)</summary>\n\n" +
+		"- grype <code>go.mod</code> <code>CVE-2025-0001</code>: vulnerable\n\n</details>\n\n"
+	if builder.String() != want {
+		t.Errorf("output = %q, want %q", builder.String(), want)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingMapping   = fmt.Errorf("mapping has neither key, override value nor default value")
+	ErrUnknownInputType = fmt.Errorf("unknown findings input type")
+)
+
+// FindingsPlan is a JSONInfos compiled once, so that mappings errors surface before tools even run, and that keys and
+// regexes are not parsed again for each finding.
+type FindingsPlan struct {
+	jsonInfo JSONInfos
+	mappings compiledMappings
+	// Keys of all mappings, used to fan out wildcards
+	keys []string
+	// Parsed paths of arrays to fan out, by key prefix
+	wildcards map[string][]queryStep
+	// Compiled Regex of "regex" type
+	regex *regexp.Regexp
+}
+
+type compiledMapping struct {
+	info        JSONMappingInfo
+	steps       []queryStep
+	transformer *regexp.Regexp
+	selector    *regexp.Regexp
+	// Parsed default value of int mappings
+	defaultInt int
+	suffix     *compiledMapping
+}
+
+type compiledMappings struct {
+	toolName  *compiledMapping
+	ruleID    *compiledMapping
+	level     *compiledMapping
+	filePath  *compiledMapping
+	startLine *compiledMapping
+	endLine   *compiledMapping
+	startCol  *compiledMapping
+	endCol    *compiledMapping
+	message   *compiledMapping
+	helpURI   *compiledMapping
+	tags      *compiledMapping
+	category  *compiledMapping
+	fix       compiledFixMappings
+}
+
+type compiledFixMappings struct {
+	baseArrayKey   string
+	baseArraySteps []queryStep
+	description    *compiledMapping
+	filePath       *compiledMapping
+	startLine      *compiledMapping
+	endLine        *compiledMapping
+	startCol       *compiledMapping
+	endCol         *compiledMapping
+	text           *compiledMapping
+}
+
+func compileMapping(name string, info JSONMappingInfo, isInt bool) (*compiledMapping, error) {
+	mapping := compiledMapping{
+		info:        info,
+		steps:       nil,
+		transformer: nil,
+		selector:    nil,
+		defaultInt:  0,
+		suffix:      nil,
+	}
+
+	steps, err := parseQuery(info.Key)
+	if err != nil {
+		return nil, fmt.Errorf("mapping %s: error parsing key %s: %w", name, info.Key, err)
+	}
+
+	mapping.steps = steps
+
+	if info.ValueTransformerRegex != "" {
+		mapping.transformer, err = regexp.Compile(info.ValueTransformerRegex)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %s: error compiling value transformer regex: %w", name, err)
+		}
+	}
+
+	if info.GlobalSelectorRegex != "" {
+		mapping.selector, err = regexp.Compile(info.GlobalSelectorRegex)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %s: error compiling global selector regex: %w", name, err)
+		}
+	}
+
+	if isInt && info.DefaultValue != "" {
+		mapping.defaultInt, err = strconv.Atoi(info.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %s: error converting default value %s to int: %w", name, info.DefaultValue, err)
+		}
+	}
+
+	if info.Suffix != nil {
+		mapping.suffix, err = compileMapping(name+".suffix", *info.Suffix, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &mapping, nil
+}
+
+func compileMappings(mappings JSONToFindingsMappings) (compiledMappings, error) {
+	var compiled compiledMappings
+
+	for _, m := range []struct {
+		name   string
+		info   JSONMappingInfo
+		isInt  bool
+		target **compiledMapping
+	}{
+		{"toolName", mappings.ToolName, false, &compiled.toolName},
+		{"ruleId", mappings.RuleID, false, &compiled.ruleID},
+		{"level", mappings.Level, false, &compiled.level},
+		{"filePath", mappings.FilePath, false, &compiled.filePath},
+		{"startLine", mappings.StartLine, true, &compiled.startLine},
+		{"endLine", mappings.EndLine, true, &compiled.endLine},
+		{"startCol", mappings.StartCol, true, &compiled.startCol},
+		{"endCol", mappings.EndCol, true, &compiled.endCol},
+		{"message", mappings.Message, false, &compiled.message},
+		{"helpUri", mappings.HelpURI, false, &compiled.helpURI},
+		{"tags", mappings.Tags, false, &compiled.tags},
+		{"category", mappings.Category, false, &compiled.category},
+		{"fix.description", mappings.Fix.Description, false, &compiled.fix.description},
+		{"fix.filePath", mappings.Fix.FilePath, false, &compiled.fix.filePath},
+		{"fix.startLine", mappings.Fix.StartLine, true, &compiled.fix.startLine},
+		{"fix.endLine", mappings.Fix.EndLine, true, &compiled.fix.endLine},
+		{"fix.startCol", mappings.Fix.StartCol, true, &compiled.fix.startCol},
+		{"fix.endCol", mappings.Fix.EndCol, true, &compiled.fix.endCol},
+		{"fix.text", mappings.Fix.Text, false, &compiled.fix.text},
+	} {
+		mapping, err := compileMapping(m.name, m.info, m.isInt)
+		if err != nil {
+			return compiled, err
+		}
+
+		*m.target = mapping
+	}
+
+	steps, err := parseQuery(mappings.Fix.BaseArrayKey)
+	if err != nil {
+		return compiled, fmt.Errorf("mapping fix.baseArrayKey: error parsing key %s: %w", mappings.Fix.BaseArrayKey, err)
+	}
+
+	compiled.fix.baseArrayKey = mappings.Fix.BaseArrayKey
+	compiled.fix.baseArraySteps = steps
+
+	return compiled, nil
+}
+
+// Check that mappings of mandatory finding fields have a source, as findings would otherwise be invalid.
+func checkMandatoryMappings(mappings JSONToFindingsMappings) error {
+	for _, m := range []struct {
+		name string
+		info JSONMappingInfo
+	}{
+		{"toolName", mappings.ToolName},
+		{"ruleId", mappings.RuleID},
+		{"level", mappings.Level},
+		{"filePath", mappings.FilePath},
+		{"message", mappings.Message},
+	} {
+		if m.info.Key == "" && m.info.OverrideValue == "" && m.info.DefaultValue == "" {
+			return fmt.Errorf("mapping %s: %w", m.name, ErrMissingMapping)
+		}
+	}
+
+	return nil
+}
+
+// CompileJSONInfos validates given JSONInfos and compiles it into a reusable plan.
+func CompileJSONInfos(jsonInfo JSONInfos) (*FindingsPlan, error) {
+	if jsonInfo.IsUnset() {
+		jsonInfo.Type = "none"
+	}
+
+	plan := FindingsPlan{
+		jsonInfo:  jsonInfo,
+		mappings:  compiledMappings{},
+		keys:      nil,
+		wildcards: map[string][]queryStep{},
+		regex:     nil,
+	}
+
+	// Text and XML types set keys of mappings that are not set, according to the keys they produce
+	switch jsonInfo.Type {
+	case "", "stream", "object", "none", "plain", "sarif":
+	case "regex":
+		if jsonInfo.Regex == "" {
+			return nil, ErrNoRegex
+		}
+
+		exp, err := regexp.Compile("(?m)" + jsonInfo.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling regex: %w", err)
+		}
+
+		setDefaultKeys(regexGroupsMappings(&plan.jsonInfo.Mappings), func(group string) bool {
+			return exp.SubexpIndex(group) != -1
+		})
+
+		plan.regex = exp
+	case "checkstyle-xml":
+		setDefaultKeys(checkstyleKeysMappings(&plan.jsonInfo.Mappings), func(string) bool { return true })
+	case "junit-xml":
+		setJUnitDefaults(&plan.jsonInfo.Mappings)
+	default:
+		return nil, fmt.Errorf("%s: %w", jsonInfo.Type, ErrUnknownInputType)
+	}
+
+	// SARIF logs are parsed without mappings, and plain outputs only use override values
+	if jsonInfo.Type != "sarif" && jsonInfo.Type != "none" && jsonInfo.Type != "plain" {
+		err := checkMandatoryMappings(plan.jsonInfo.Mappings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mappings, err := compileMappings(plan.jsonInfo.Mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.mappings = mappings
+	plan.keys = mappingsKeys(plan.jsonInfo.Mappings)
+
+	for _, key := range plan.keys {
+		for i := strings.Index(key, KeyWildcard); i != -1; {
+			prefix := key[:i]
+
+			// Keys were parsed along with their mapping
+			plan.wildcards[prefix], _ = parseQuery(prefix)
+
+			next := strings.Index(key[i+len(KeyWildcard):], KeyWildcard)
+			if next == -1 {
+				break
+			}
+
+			i += len(KeyWildcard) + next
+		}
+	}
+
+	return &plan, nil
+}
+
+// Parse findings from given tool output, see FindingsFromJSON.
+func (p *FindingsPlan) FindingsFromJSON(str string) ([]Finding, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	var findings []Finding
+
+	err := p.Decode(strings.NewReader(str), func(finding Finding) {
+		findings = append(findings, finding)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+// Decode findings from given reader as it is written, see DecodeFindings.
+func (p *FindingsPlan) Decode(r io.Reader, emit func(Finding)) error {
+	switch p.jsonInfo.Type {
+	case "sarif":
+		return decodeSarifFindings(r, p.jsonInfo.Mappings, emit)
+	case "regex":
+		return decodeRegexFindings(r, p, emit)
+	case "checkstyle-xml":
+		return decodeCheckstyleXMLFindings(r, p, emit)
+	case "junit-xml":
+		return decodeJUnitXMLFindings(r, p, emit)
+	}
+
+	return decodeJSONFindings(r, p, emit)
+}
@@ -0,0 +1,242 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fullMappings() JSONToFindingsMappings {
+	return JSONToFindingsMappings{
+		ToolName: JSONMappingInfo{OverrideValue: "tool"},
+		RuleID:   JSONMappingInfo{Key: "rule"},
+		Level:    JSONMappingInfo{DefaultValue: "warning"},
+		FilePath: JSONMappingInfo{Key: "file"},
+		Message:  JSONMappingInfo{Key: "message"},
+	}
+}
+
+func TestCompileJSONInfos(t *testing.T) {
+	t.Parallel()
+
+	withoutRule := fullMappings()
+	withoutRule.RuleID = JSONMappingInfo{}
+
+	tests := []struct {
+		name     string
+		jsonInfo JSONInfos
+		wantType string
+		wantErr  error
+	}{
+		{name: "unset is none", jsonInfo: JSONInfos{}, wantType: "none"},
+		{name: "none", jsonInfo: JSONInfos{Type: "none"}, wantType: "none"},
+		{name: "plain", jsonInfo: JSONInfos{Type: "plain", Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}}}, wantType: "plain"},
+		{name: "sarif", jsonInfo: JSONInfos{Type: "sarif"}, wantType: "sarif"},
+		{name: "default array", jsonInfo: JSONInfos{Mappings: fullMappings()}, wantType: ""},
+		{name: "stream", jsonInfo: JSONInfos{Type: "stream", Mappings: fullMappings()}, wantType: "stream"},
+		{name: "only read from stderr", jsonInfo: JSONInfos{ReadFromStderr: true}, wantErr: ErrMissingMapping},
+		{name: "missing mandatory mapping", jsonInfo: JSONInfos{Type: "object", Mappings: withoutRule}, wantErr: ErrMissingMapping},
+		{name: "unknown type", jsonInfo: JSONInfos{Type: "yaml", Mappings: fullMappings()}, wantErr: ErrUnknownInputType},
+		{name: "regex without regex", jsonInfo: JSONInfos{Type: "regex", Mappings: fullMappings()}, wantErr: ErrNoRegex},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := CompileJSONInfos(test.jsonInfo)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("CompileJSONInfos() error = %v, want %v", err, test.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if plan.jsonInfo.Type != test.wantType {
+				t.Errorf("type = %q, want %q", plan.jsonInfo.Type, test.wantType)
+			}
+		})
+	}
+}
+
+func TestCompileJSONInfosWildcards(t *testing.T) {
+	t.Parallel()
+
+	mappings := fullMappings()
+	mappings.FilePath = JSONMappingInfo{Key: "locations[*].path"}
+	mappings.StartLine = JSONMappingInfo{Key: "locations[*].lines[*].start"}
+
+	plan, err := CompileJSONInfos(JSONInfos{Type: "object", Mappings: mappings})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefixes := []string{}
+	for prefix := range plan.wildcards {
+		prefixes = append(prefixes, prefix)
+	}
+
+	slices.Sort(prefixes)
+
+	want := []string{"locations", "locations[*].lines"}
+	if !reflect.DeepEqual(prefixes, want) {
+		t.Errorf("wildcard prefixes = %v, want %v", prefixes, want)
+	}
+}
+
+func TestFindingsPlanDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		typ    string
+		output string
+		want   []string
+	}{
+		{name: "array", typ: "", output: `[{"rule":"a","file":"x","message":"m"},{"rule":"b","file":"y","message":"m"}]`, want: []string{"a", "b"}},
+		{name: "stream", typ: "stream", output: "{\"rule\":\"a\",\"file\":\"x\",\"message\":\"m\"}\n{\"rule\":\"b\",\"file\":\"y\",\"message\":\"m\"}\n", want: []string{"a", "b"}},
+		{name: "object", typ: "object", output: `{"rule":"a","file":"x","message":"m"}`, want: []string{"a"}},
+		{name: "empty object output", typ: "object", output: "", want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := CompileJSONInfos(JSONInfos{Type: test.typ, Mappings: fullMappings()})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rules := []string{}
+
+			err = plan.Decode(strings.NewReader(test.output), func(finding Finding) {
+				rules = append(rules, finding.RuleID)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(rules, test.want) {
+				t.Errorf("rules = %v, want %v", rules, test.want)
+			}
+		})
+	}
+}
+
+func TestFindingsPlanDecodeAsWritten(t *testing.T) {
+	t.Parallel()
+
+	toolMappings := JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}}
+
+	tests := []struct {
+		name     string
+		jsonInfo JSONInfos
+		first    string
+		rest     string
+		want     int
+	}{
+		{
+			name:     "array",
+			jsonInfo: JSONInfos{Type: "", Mappings: fullMappings()},
+			first:    `[{"rule":"a","file":"x","message":"m"},`,
+			rest:     `{"rule":"b","file":"y","message":"m"}]`,
+			want:     2,
+		},
+		{
+			name:     "sarif",
+			jsonInfo: JSONInfos{Type: "sarif", Mappings: toolMappings},
+			first:    `{"runs": [{"tool": {"driver": {"name": "tool"}}, "results": [{"ruleId": "a", "message": {"text": "m"}},`,
+			rest:     `{"ruleId": "b", "message": {"text": "m"}}]}]}`,
+			want:     2,
+		},
+		{
+			name:     "checkstyle",
+			jsonInfo: JSONInfos{Type: "checkstyle-xml", Mappings: toolMappings},
+			first:    `<checkstyle><file name="a.go"><error line="1" severity="error" message="m" source="a"/>`,
+			rest:     `<error line="2" severity="error" message="m" source="b"/></file></checkstyle>`,
+			want:     2,
+		},
+		{
+			name:     "junit",
+			jsonInfo: JSONInfos{Type: "junit-xml", Mappings: toolMappings},
+			first:    `<testsuite name="suite"><testcase name="a"><failure message="m"/></testcase>`,
+			rest:     `<testcase name="b"><failure message="m"/></testcase></testsuite>`,
+			want:     2,
+		},
+		{
+			name: "regex",
+			jsonInfo: JSONInfos{
+				Type:  "regex",
+				Regex: `^(?P<file>[^:\n]+):(?P<line>\d+): (?P<message>.*)$`,
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "tool"},
+					RuleID:   JSONMappingInfo{OverrideValue: "rule"},
+					Level:    JSONMappingInfo{OverrideValue: "notice"},
+				},
+			},
+			// Matches are emitted once the lines following them are read
+			first: strings.Repeat("a.go:1: m\n", 2*regexWindowLines),
+			rest:  "b.go:2: m\n",
+			want:  2*regexWindowLines + 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := CompileJSONInfos(test.jsonInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reader, writer := io.Pipe()
+			emitted := make(chan struct{})
+			written := make(chan bool, 1)
+
+			go func() {
+				_, _ = writer.Write([]byte(test.first))
+
+				select {
+				case <-emitted:
+					written <- true
+				case <-time.After(5 * time.Second):
+					written <- false
+				}
+
+				_, _ = writer.Write([]byte(test.rest))
+				_ = writer.Close()
+			}()
+
+			count := 0
+
+			err = plan.Decode(reader, func(Finding) {
+				if count == 0 {
+					close(emitted)
+				}
+
+				count++
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !<-written {
+				t.Error("no finding was emitted before the output was over")
+			}
+
+			if count != test.want {
+				t.Errorf("got %d findings, want %d", count, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidQuery = fmt.Errorf("invalid query")
+
+type queryStepKind int
+
+const (
+	// Object member, as in `foo` or `["foo.bar"]`
+	queryStepMember queryStepKind = iota
+	// Array element, as in `[2]`, negative indices counting from the end
+	queryStepIndex
+	// Array elements fanned out into several findings, as in `[*]`
+	queryStepWildcard
+	// First array element matching a filter, as in `[?name=="foo"]`
+	queryStepFilter
+)
+
+type queryStep struct {
+	kind   queryStepKind
+	name   string
+	index  int
+	filter *queryFilter
+}
+
+type queryFilter struct {
+	path []queryStep
+	// One of `==`, `!=`, `=~`, or empty to only check existence
+	op    string
+	value string
+	regex *regexp.Regexp
+}
+
+// Parse a query, which is a dot-separated list of object members, optionally followed by selectors:
+//   - `foo.bar` selects member `bar` of member `foo`, selecting first element of arrays met on the way
+//   - `foo\.bar` or `["foo.bar"]` selects member `foo.bar`
+//   - `foo[2]` selects third element of `foo`, `foo[-1]` its last element
+//   - `foo[*]` fans out elements of `foo`, see KeyWildcard
+//   - `foo[?bar.baz=="qux"]` selects first element of `foo` which `bar.baz` is `qux`, `!=` negates the comparison, `=~`
+//     matches a regex, and `foo[?bar]` selects first element having `bar`
+func parseQuery(query string) ([]queryStep, error) {
+	var (
+		steps []queryStep
+		name  strings.Builder
+	)
+
+	// Whether a member name is being read, so that empty names such as in `foo..bar` are kept
+	reading := true
+
+	flush := func() {
+		if reading {
+			steps = append(steps, queryStep{kind: queryStepMember, name: name.String(), index: 0, filter: nil})
+		}
+
+		name.Reset()
+
+		reading = false
+	}
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; c {
+		case '\\':
+			if i+1 < len(query) {
+				i++
+				name.WriteByte(query[i])
+			}
+
+			reading = true
+		case '.':
+			flush()
+
+			reading = true
+		case '[':
+			if name.Len() > 0 {
+				flush()
+			}
+
+			reading = false
+
+			end, err := closingBracket(query, i)
+			if err != nil {
+				return nil, err
+			}
+
+			step, err := parseSelector(query[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, step)
+			i = end
+		default:
+			name.WriteByte(c)
+
+			reading = true
+		}
+	}
+
+	if reading && (name.Len() > 0 || len(steps) > 0) {
+		flush()
+	}
+
+	return steps, nil
+}
+
+// Index of the bracket closing the one at given index, skipping quoted strings and nested brackets, as filter paths may
+// have selectors.
+func closingBracket(query string, open int) (int, error) {
+	quoted := false
+	depth := 0
+
+	for i := open + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case '[':
+			if !quoted {
+				depth++
+			}
+		case ']':
+			if quoted {
+				continue
+			}
+
+			if depth == 0 {
+				return i, nil
+			}
+
+			depth--
+		}
+	}
+
+	return 0, fmt.Errorf("unclosed bracket in %s: %w", query, ErrInvalidQuery)
+}
+
+func parseSelector(selector string) (queryStep, error) {
+	step := queryStep{kind: queryStepMember, name: "", index: 0, filter: nil}
+
+	switch {
+	case selector == "*":
+		step.kind = queryStepWildcard
+	case strings.HasPrefix(selector, "\""):
+		name, err := strconv.Unquote(selector)
+		if err != nil {
+			return step, fmt.Errorf("member %s: %w", selector, ErrInvalidQuery)
+		}
+
+		step.name = name
+	case strings.HasPrefix(selector, "?"):
+		filter, err := parseFilter(selector[1:])
+		if err != nil {
+			return step, err
+		}
+
+		step.kind = queryStepFilter
+		step.filter = filter
+	default:
+		index, err := strconv.Atoi(selector)
+		if err != nil {
+			return step, fmt.Errorf("selector %s: %w", selector, ErrInvalidQuery)
+		}
+
+		step.kind = queryStepIndex
+		step.index = index
+	}
+
+	return step, nil
+}
+
+// Index and operator of the first comparison of a filter, skipping escaped characters and quoted member names of its
+// path, or -1 if filter only checks existence.
+func filterOperator(filter string) (int, string) {
+	quoted := false
+
+	for i := 0; i < len(filter); i++ {
+		switch {
+		case filter[i] == '\\':
+			i++
+		case filter[i] == '"':
+			quoted = !quoted
+		case !quoted:
+			for _, op := range []string{"==", "!=", "=~"} {
+				if strings.HasPrefix(filter[i:], op) {
+					return i, op
+				}
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+func parseFilter(filter string) (*queryFilter, error) {
+	f := queryFilter{path: nil, op: "", value: "", regex: nil}
+	path := filter
+
+	// Value may contain operators as well, such as a regex matching `==`
+	index, op := filterOperator(filter)
+	if index != -1 {
+		value := strings.TrimSpace(filter[index+len(op):])
+		if strings.HasPrefix(value, "\"") {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("filter value %s: %w", value, ErrInvalidQuery)
+			}
+
+			value = unquoted
+		}
+
+		path = filter[:index]
+		f.op = op
+		f.value = value
+	}
+
+	if f.op == "=~" {
+		regex, err := regexp.Compile(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("filter regex %s: %w", f.value, ErrInvalidQuery)
+		}
+
+		f.regex = regex
+	}
+
+	steps, err := parseQuery(strings.TrimSpace(path))
+	if err != nil {
+		return nil, err
+	}
+
+	f.path = steps
+
+	return &f, nil
+}
+
+func (f *queryFilter) matches(element any) bool {
+	value, found := evalQuery(element, f.path)
+
+	switch f.op {
+	case "":
+		return found
+	case "==":
+		return found && fmt.Sprint(value) == f.value
+	case "!=":
+		return !found || fmt.Sprint(value) != f.value
+	case "=~":
+		return found && f.regex.MatchString(fmt.Sprint(value))
+	default:
+		return false
+	}
+}
+
+// Index of the element selected by given step in given array, if any.
+func selectedIndex(array []any, step queryStep) (int, bool) {
+	switch step.kind {
+	case queryStepIndex:
+		index := step.index
+		if index < 0 {
+			index += len(array)
+		}
+
+		return index, index >= 0 && index < len(array)
+	case queryStepFilter:
+		index := slices.IndexFunc(array, step.filter.matches)
+
+		return index, index != -1
+	default:
+		// Arrays met on the way resolve to their first element, as do unresolved wildcards
+		return 0, len(array) > 0
+	}
+}
+
+// Evaluate query steps against given value, returning whether a non-null value was found.
+func evalQuery(value any, steps []queryStep) (any, bool) {
+	for _, step := range steps {
+		if step.kind == queryStepMember {
+			if array, ok := value.([]any); ok {
+				if len(array) == 0 {
+					return nil, false
+				}
+
+				value = array[0]
+			}
+
+			m, ok := value.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+
+			value = m[step.name]
+		} else {
+			array, ok := value.([]any)
+			if !ok {
+				// Fanned out wildcards already hold a single element
+				if step.kind == queryStepWildcard {
+					continue
+				}
+
+				return nil, false
+			}
+
+			index, found := selectedIndex(array, step)
+			if !found {
+				return nil, false
+			}
+
+			value = array[index]
+		}
+
+		if value == nil {
+			return nil, false
+		}
+	}
+
+	return value, value != nil
+}
+
+// Copy of given value, in which the value selected by query steps is replaced. Only objects and arrays along the way
+// are copied, missing objects being created.
+func replaceAt(value any, steps []queryStep, newValue any) any {
+	if len(steps) == 0 {
+		return newValue
+	}
+
+	step := steps[0]
+
+	if array, ok := value.([]any); ok {
+		index, found := selectedIndex(array, step)
+		if !found {
+			return value
+		}
+
+		clone := slices.Clone(array)
+
+		if step.kind == queryStepMember {
+			clone[index] = replaceAt(array[index], steps, newValue)
+		} else {
+			clone[index] = replaceAt(array[index], steps[1:], newValue)
+		}
+
+		return clone
+	}
+
+	if step.kind == queryStepWildcard {
+		return replaceAt(value, steps[1:], newValue)
+	}
+
+	if step.kind != queryStepMember {
+		return value
+	}
+
+	m, _ := value.(map[string]any)
+
+	clone := make(map[string]any, len(m)+1)
+	for k, v := range m {
+		clone[k] = v
+	}
+
+	clone[step.name] = replaceAt(m[step.name], steps[1:], newValue)
+
+	return clone
+}
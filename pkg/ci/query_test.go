@@ -0,0 +1,143 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const queryTestDocument = `{
+	"name": "root",
+	"dotted.key": "dotted",
+	"location": {"path": "main.go", "line": 3},
+	"items": [
+		{"name": "first", "message": "a==b", "tags": ["x"]},
+		{"name": "second", "message": "a=~b", "level": "error"},
+		{"name": "third=~", "message": "c", "level": null}
+	]
+}`
+
+func queryTestValue(t *testing.T) any {
+	t.Helper()
+
+	var value any
+
+	err := json.Unmarshal([]byte(queryTestDocument), &value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return value
+}
+
+func TestEvalQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query     string
+		want      any
+		wantFound bool
+	}{
+		{query: "name", want: "root", wantFound: true},
+		{query: "location.path", want: "main.go", wantFound: true},
+		{query: "location.line", want: float64(3), wantFound: true},
+		{query: `dotted\.key`, want: "dotted", wantFound: true},
+		{query: `["dotted.key"]`, want: "dotted", wantFound: true},
+		{query: "missing", want: nil, wantFound: false},
+		{query: "location.missing", want: nil, wantFound: false},
+		// Arrays met on the way resolve to their first element
+		{query: "items.name", want: "first", wantFound: true},
+		{query: "items[1].name", want: "second", wantFound: true},
+		{query: "items[-1].name", want: "third=~", wantFound: true},
+		{query: "items[3].name", want: nil, wantFound: false},
+		{query: `items[?name=="second"].message`, want: "a=~b", wantFound: true},
+		{query: `items[?name!="first"].name`, want: "second", wantFound: true},
+		{query: `items[?level].name`, want: "second", wantFound: true},
+		{query: `items[?tags].name`, want: "first", wantFound: true},
+		{query: `items[?name=~"^th"].name`, want: "third=~", wantFound: true},
+		// Operators are the first ones of filters, values may contain other ones
+		{query: `items[?message=="a=~b"].name`, want: "second", wantFound: true},
+		{query: `items[?message=~"a==b"].name`, want: "first", wantFound: true},
+		{query: `items[?message!="a==b"].name`, want: "second", wantFound: true},
+		// Quoted member names of filter paths may contain operators as well
+		{query: `items[?["name"]=="third=~"].message`, want: "c", wantFound: true},
+		{query: `items[?name=="none"].name`, want: nil, wantFound: false},
+		{query: "items[?level].level", want: "error", wantFound: true},
+		{query: "items[2].level", want: nil, wantFound: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := parseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, found := evalQuery(queryTestValue(t), steps)
+			if found != test.wantFound || !reflect.DeepEqual(got, test.want) {
+				t.Errorf("evalQuery(%s) = %v, %t, want %v, %t", test.query, got, found, test.want, test.wantFound)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"items[0",
+		"items[foo]",
+		`items["unterminated]`,
+		`items[?name=="unterminated]`,
+		`items[?name=~"("]`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseQuery(query)
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("parseQuery(%s) error = %v, want %v", query, err, ErrInvalidQuery)
+			}
+		})
+	}
+}
+
+func TestReplaceAt(t *testing.T) {
+	t.Parallel()
+
+	value := queryTestValue(t)
+
+	steps, err := parseQuery(`items[?name=="second"].message`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaced := replaceAt(value, steps, "replaced")
+
+	got, _ := evalQuery(replaced, steps)
+	if got != "replaced" {
+		t.Errorf("replaced value = %v, want replaced", got)
+	}
+
+	// Original value is left untouched
+	got, _ = evalQuery(value, steps)
+	if got != "a=~b" {
+		t.Errorf("original value = %v, want a=~b", got)
+	}
+
+	steps, err = parseQuery("location.column")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, found := evalQuery(replaceAt(value, steps, float64(2)), steps)
+	if !found || got != float64(2) {
+		t.Errorf("created value = %v, %t, want 2, true", got, found)
+	}
+}
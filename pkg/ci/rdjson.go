@@ -0,0 +1,162 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// See https://github.com/reviewdog/reviewdog/tree/master/proto/rdf
+type rdjsonResult struct {
+	Source      *rdjsonSource      `json:"source,omitempty"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Source      rdjsonSource       `json:"source"`
+	Code        rdjsonCode         `json:"code"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Map normalized finding levels (see validateFindings) to reviewdog severities
+func rdjsonSeverity(level string) string {
+	switch level {
+	case "error":
+		return "ERROR"
+	case "warning":
+		return "WARNING"
+	case "notice":
+		return "INFO"
+	default:
+		return "UNKNOWN_SEVERITY"
+	}
+}
+
+func rdjsonRangeFromFinding(f *Finding) *rdjsonRange {
+	if f.StartLine <= 0 {
+		return nil
+	}
+
+	rng := rdjsonRange{
+		Start: rdjsonPosition{
+			Line:   f.StartLine,
+			Column: f.StartCol,
+		},
+		End: nil,
+	}
+
+	switch {
+	case f.EndLine > f.StartLine:
+		rng.End = &rdjsonPosition{
+			Line:   f.EndLine,
+			Column: f.EndCol,
+		}
+	case f.EndCol > f.StartCol:
+		rng.End = &rdjsonPosition{
+			Line:   f.StartLine,
+			Column: f.EndCol,
+		}
+	}
+
+	return &rng
+}
+
+// Suggestions of the finding fix, only replacements of the finding file being supported
+func rdjsonSuggestionsFromFinding(f *Finding) []rdjsonSuggestion {
+	if f.Fix == nil {
+		return nil
+	}
+
+	var suggestions []rdjsonSuggestion
+
+	for _, replacement := range f.Fix.Replacements {
+		if replacement.FilePath != f.FilePath {
+			continue
+		}
+
+		// Replacements end column is exclusive, as reviewdog one
+		suggestions = append(suggestions, rdjsonSuggestion{
+			Range: rdjsonRange{
+				Start: rdjsonPosition{Line: replacement.StartLine, Column: replacement.StartCol},
+				End:   &rdjsonPosition{Line: replacement.EndLine, Column: replacement.EndCol},
+			},
+			Text: replacement.Text,
+		})
+	}
+
+	return suggestions
+}
+
+func rdjsonFromFindings(findings []*Finding) rdjsonResult {
+	result := rdjsonResult{
+		Source:      nil,
+		Diagnostics: []rdjsonDiagnostic{},
+	}
+
+	for _, finding := range findings {
+		result.Diagnostics = append(result.Diagnostics, rdjsonDiagnostic{
+			Message: finding.Message,
+			Location: rdjsonLocation{
+				Path:  finding.FilePath,
+				Range: rdjsonRangeFromFinding(finding),
+			},
+			Severity: rdjsonSeverity(finding.Level),
+			Source: rdjsonSource{
+				Name: finding.ToolName,
+			},
+			Code: rdjsonCode{
+				Value: finding.RuleID,
+				URL:   finding.HelpURI,
+			},
+			Suggestions: rdjsonSuggestionsFromFinding(finding),
+		})
+	}
+
+	return result
+}
+
+func printFindingsRdjson(w io.Writer, findings []*Finding) error {
+	output, err := json.MarshalIndent(rdjsonFromFindings(findings), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling findings to rdjson: %w", err)
+	}
+
+	fmt.Fprintln(w, string(output))
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRdjsonFromFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{
+			ToolName:  "golangci-lint",
+			RuleID:    "gofmt",
+			Level:     "warning",
+			FilePath:  "main.go",
+			StartLine: 3,
+			EndLine:   3,
+			StartCol:  1,
+			EndCol:    5,
+			Message:   "not formatted",
+			HelpURI:   "https://example.com/gofmt",
+			Fix: &Fix{Replacements: []Replacement{
+				{FilePath: "main.go", StartLine: 3, EndLine: 3, StartCol: 1, EndCol: 5, Text: "text"},
+				// Suggestions can only apply to the finding file
+				{FilePath: "other.go", StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 1, Text: "other"},
+			}},
+		},
+		{ToolName: "semgrep", RuleID: "sql", Level: "error", FilePath: "db.go", StartLine: 4, EndLine: 6, StartCol: 2, EndCol: 3, Message: "sql injection"},
+		{ToolName: "grype", RuleID: "CVE-2025-0001", Level: "notice", FilePath: "go.mod", Message: "vulnerable"},
+		{ToolName: "tool", RuleID: "rule", Level: "debug", FilePath: "a.go", StartLine: 1, Message: "debug"},
+	}
+
+	want := rdjsonResult{
+		Diagnostics: []rdjsonDiagnostic{
+			{
+				Message:  "not formatted",
+				Location: rdjsonLocation{Path: "main.go", Range: &rdjsonRange{Start: rdjsonPosition{Line: 3, Column: 1}, End: &rdjsonPosition{Line: 3, Column: 5}}},
+				Severity: "WARNING",
+				Source:   rdjsonSource{Name: "golangci-lint"},
+				Code:     rdjsonCode{Value: "gofmt", URL: "https://example.com/gofmt"},
+				Suggestions: []rdjsonSuggestion{
+					{Range: rdjsonRange{Start: rdjsonPosition{Line: 3, Column: 1}, End: &rdjsonPosition{Line: 3, Column: 5}}, Text: "text"},
+				},
+			},
+			{
+				Message:  "sql injection",
+				Location: rdjsonLocation{Path: "db.go", Range: &rdjsonRange{Start: rdjsonPosition{Line: 4, Column: 2}, End: &rdjsonPosition{Line: 6, Column: 3}}},
+				Severity: "ERROR",
+				Source:   rdjsonSource{Name: "semgrep"},
+				Code:     rdjsonCode{Value: "sql"},
+			},
+			{
+				Message:  "vulnerable",
+				Location: rdjsonLocation{Path: "go.mod"},
+				Severity: "INFO",
+				Source:   rdjsonSource{Name: "grype"},
+				Code:     rdjsonCode{Value: "CVE-2025-0001"},
+			},
+			{
+				Message:  "debug",
+				Location: rdjsonLocation{Path: "a.go", Range: &rdjsonRange{Start: rdjsonPosition{Line: 1}}},
+				Severity: "UNKNOWN_SEVERITY",
+				Source:   rdjsonSource{Name: "tool"},
+				Code:     rdjsonCode{Value: "rule"},
+			},
+		},
+	}
+
+	got := rdjsonFromFindings(findings)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rdjsonFromFindings() =\n%+v\nwant\n%+v", got, want)
+	}
+}
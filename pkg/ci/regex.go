@@ -0,0 +1,142 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNoRegex = fmt.Errorf("regex is required to parse text output")
+
+// Number of lines of text output a regex match can span at most, see decodeRegexFindings
+const regexWindowLines = 1000
+
+// Mappings which key defaults to the named group of same name, when neither key nor override value is set.
+func regexGroupsMappings(mappings *JSONToFindingsMappings) map[string]*JSONMappingInfo {
+	return map[string]*JSONMappingInfo{
+		"file":    &mappings.FilePath,
+		"line":    &mappings.StartLine,
+		"col":     &mappings.StartCol,
+		"level":   &mappings.Level,
+		"rule":    &mappings.RuleID,
+		"message": &mappings.Message,
+	}
+}
+
+// Set key of mappings which neither key nor override value is set, if given key is available.
+func setDefaultKeys(defaults map[string]*JSONMappingInfo, available func(key string) bool) {
+	for key, mapping := range defaults {
+		if mapping.Key == "" && mapping.OverrideValue == "" && available(key) {
+			mapping.Key = key
+		}
+	}
+}
+
+// Parse findings from text output as it is written, such as `file:line:col: message` lines of compilers. Each match of
+// the regex is converted to a JSON object which keys are the named groups, mappings being then applied as for JSON
+// outputs. `^` and `$` match at line boundaries, and a match can span several lines if the regex matches line breaks.
+// Regex is matched against a window of lines so that memory is bounded, matches spanning more than regexWindowLines lines
+// being truncated.
+func decodeRegexFindings(r io.Reader, plan *FindingsPlan, emit func(Finding)) error {
+	exp := plan.regex
+	reader := bufio.NewReader(r)
+	lines := []string{}
+	// Offset in the window up to which text was part of an emitted match
+	matched := 0
+
+	// Emit matches starting before the last regexWindowLines lines of the window, which may otherwise continue in lines
+	// not read yet, unless the output is over, then drop lines that can not be part of a match anymore.
+	matchWindow := func(over bool) error {
+		text := strings.Join(lines, "")
+
+		cut := len(text)
+		if !over {
+			cut -= len(strings.Join(lines[len(lines)-regexWindowLines:], ""))
+		}
+
+		end := matched
+
+		for _, match := range exp.FindAllStringSubmatchIndex(text, -1) {
+			// Matches overlapping emitted ones are found again as the window starts at a line boundary
+			if match[0] < matched {
+				continue
+			}
+
+			if match[0] >= cut {
+				break
+			}
+
+			err := emitObjectFindings(regexObject(exp, text, match), plan, emit)
+			if err != nil {
+				return err
+			}
+
+			end = match[1]
+		}
+
+		// Window keeps starting at a line boundary, so that `^` does not match within a line
+		limit := max(end, cut)
+		dropped := 0
+
+		for len(lines) > 0 && dropped+len(lines[0]) <= limit {
+			dropped += len(lines[0])
+			lines = lines[1:]
+		}
+
+		matched = max(0, limit-dropped)
+
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			lines = append(lines, line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return matchWindow(true)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error reading output: %w", err)
+		}
+
+		if len(lines) >= 2*regexWindowLines {
+			err = matchWindow(false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Convert a match of the regex to a JSON object which keys are the named groups.
+func regexObject(exp *regexp.Regexp, text string, match []int) map[string]any {
+	object := map[string]any{}
+
+	for i, group := range exp.SubexpNames() {
+		// Unnamed and unmatched groups are not found, so that default values apply
+		if group == "" || match[2*i] < 0 || match[2*i] == match[2*i+1] {
+			continue
+		}
+
+		value := text[match[2*i]:match[2*i+1]]
+
+		num, err := strconv.Atoi(value)
+		if err == nil {
+			object[group] = num
+		} else {
+			object[group] = value
+		}
+	}
+
+	return object
+}
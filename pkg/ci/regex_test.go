@@ -0,0 +1,208 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindingsFromRegex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		jsonInfo JSONInfos
+		output   string
+		want     []Finding
+	}{
+		{
+			name: "default groups",
+			jsonInfo: JSONInfos{
+				Type:  "regex",
+				Regex: `^(?P<file>[^:\n]+):(?P<line>\d+):(?P<col>\d+): \[(?P<level>\w+)\] (?P<message>.*) \((?P<rule>[\w-]+)\)$`,
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "yamllint"},
+				},
+			},
+			output: "a.yaml:1:1: [warning] missing document start (document-start)\n" +
+				"not a finding\n" +
+				"b.yaml:12:3: [error] trailing spaces (trailing-spaces)\n",
+			want: []Finding{
+				{ToolName: "yamllint", RuleID: "document-start", Level: "warning", FilePath: "a.yaml", StartLine: 1, StartCol: 1, Message: "missing document start"},
+				{ToolName: "yamllint", RuleID: "trailing-spaces", Level: "error", FilePath: "b.yaml", StartLine: 12, StartCol: 3, Message: "trailing spaces"},
+			},
+		},
+		{
+			name: "unmatched optional group and overridden mappings",
+			jsonInfo: JSONInfos{
+				Type:  "regex",
+				Regex: `^(?P<file>[^:\n]+):(?P<line>\d+):(?:(?P<col>\d+):)? (?P<message>.*)$`,
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "compiler"},
+					RuleID:   JSONMappingInfo{OverrideValue: "compile-error"},
+					Level:    JSONMappingInfo{OverrideValue: "error"},
+				},
+			},
+			output: "main.c:4: undeclared identifier\nmain.c:5:7: expected ';'\n",
+			want: []Finding{
+				{ToolName: "compiler", RuleID: "compile-error", Level: "error", FilePath: "main.c", StartLine: 4, Message: "undeclared identifier"},
+				{ToolName: "compiler", RuleID: "compile-error", Level: "error", FilePath: "main.c", StartLine: 5, StartCol: 7, Message: "expected ';'"},
+			},
+		},
+		{
+			name: "custom group names",
+			jsonInfo: JSONInfos{
+				Type:  "regex",
+				Regex: `^(?P<path>\S+) line (?P<row>\d+) (?P<code>\w+)$`,
+				Mappings: JSONToFindingsMappings{
+					ToolName:  JSONMappingInfo{OverrideValue: "tool"},
+					RuleID:    JSONMappingInfo{Key: "code"},
+					Level:     JSONMappingInfo{DefaultValue: "warning"},
+					FilePath:  JSONMappingInfo{Key: "path"},
+					StartLine: JSONMappingInfo{Key: "row"},
+					Message:   JSONMappingInfo{Key: "code", Suffix: &JSONMappingInfo{OverrideValue: " reported"}},
+				},
+			},
+			output: "src/a.py line 8 E501\n",
+			want: []Finding{
+				{ToolName: "tool", RuleID: "E501", Level: "warning", FilePath: "src/a.py", StartLine: 8, Message: "E501 reported"},
+			},
+		},
+		{
+			name: "match spanning several lines",
+			jsonInfo: JSONInfos{
+				Type:  "regex",
+				Regex: `^(?P<file>\S+):(?P<line>\d+)\n\s+(?P<message>.+)$`,
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "tool"},
+					RuleID:   JSONMappingInfo{OverrideValue: "rule"},
+					Level:    JSONMappingInfo{OverrideValue: "notice"},
+				},
+			},
+			output: "a.txt:2\n    first message\nb.txt:3\n    second message\n",
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "notice", FilePath: "a.txt", StartLine: 2, Message: "first message"},
+				{ToolName: "tool", RuleID: "rule", Level: "notice", FilePath: "b.txt", StartLine: 3, Message: "second message"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := CompileJSONInfos(test.jsonInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []Finding
+
+			err = plan.Decode(strings.NewReader(test.output), func(finding Finding) {
+				got = append(got, finding)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findings =\n%+v\nwant\n%+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRegexFindingsWindow(t *testing.T) {
+	t.Parallel()
+
+	plan, err := CompileJSONInfos(JSONInfos{
+		Type:  "regex",
+		Regex: `^(?P<file>\S+):(?P<line>\d+)\n\s+(?P<message>.+)$`,
+		Mappings: JSONToFindingsMappings{
+			ToolName: JSONMappingInfo{OverrideValue: "tool"},
+			RuleID:   JSONMappingInfo{OverrideValue: "rule"},
+			Level:    JSONMappingInfo{OverrideValue: "notice"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Matches span two lines, some of them spanning the boundaries of windows
+	count := 3*regexWindowLines + 1
+
+	var builder strings.Builder
+
+	for i := range count {
+		fmt.Fprintf(&builder, "file.txt:%d\n    message %d\n", i+1, i+1)
+	}
+
+	lines := []int{}
+
+	err = plan.Decode(strings.NewReader(builder.String()), func(finding Finding) {
+		if finding.Message != fmt.Sprintf("message %d", finding.StartLine) {
+			t.Errorf("finding at line %d has message %q", finding.StartLine, finding.Message)
+		}
+
+		lines = append(lines, finding.StartLine)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != count {
+		t.Fatalf("got %d findings, want %d", len(lines), count)
+	}
+
+	for i, line := range lines {
+		if line != i+1 {
+			t.Fatalf("finding %d is at line %d, want %d", i, line, i+1)
+		}
+	}
+}
+
+func TestCompileRegexErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		jsonInfo JSONInfos
+		wantErr  error
+	}{
+		{
+			name:     "missing regex",
+			jsonInfo: JSONInfos{Type: "regex"},
+			wantErr:  ErrNoRegex,
+		},
+		{
+			name: "no source for rule",
+			jsonInfo: JSONInfos{
+				Type:     "regex",
+				Regex:    `^(?P<file>[^:\n]+):(?P<line>\d+): \[(?P<level>\w+)\] (?P<message>.*)$`,
+				Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}},
+			},
+			wantErr: ErrMissingMapping,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := CompileJSONInfos(test.jsonInfo)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("CompileJSONInfos() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+
+	_, err := CompileJSONInfos(JSONInfos{Type: "regex", Regex: `(?P<file>`})
+	if err == nil {
+		t.Error("CompileJSONInfos() of an invalid regex succeeded")
+	}
+}
@@ -0,0 +1,816 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	sarifVersion         = "2.1.0"
+	sarifSchema          = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifFingerprintName = "kemaFingerprint/v1"
+	// File path of findings parsed from SARIF results that are not tied to an artifact, which relate to the repository
+	sarifRepositoryPath = "."
+)
+
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+	// Only read when parsing SARIF logs
+	Extensions []sarifDriver `json:"extensions,omitempty"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+	// Only read when parsing SARIF logs
+	GlobalMessageStrings map[string]sarifMessageString `json:"globalMessageStrings,omitempty"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+	// Only read when parsing SARIF logs
+	DefaultConfiguration *sarifRuleConfiguration       `json:"defaultConfiguration,omitempty"`
+	Properties           *sarifProperties              `json:"properties,omitempty"`
+	MessageStrings       map[string]sarifMessageString `json:"messageStrings,omitempty"`
+}
+
+type sarifMessageString struct {
+	Text string `json:"text"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID string `json:"ruleId"`
+	// Index of the rule in driver rules, -1 or absent meaning it is not known
+	RuleIndex *int `json:"ruleIndex,omitempty"`
+	// Only read when parsing SARIF logs, reference to a rule which may be defined by an extension
+	Rule *sarifRuleReference `json:"rule,omitempty"`
+	// Only read when parsing SARIF logs, evaluation state of the result, absent meaning `fail`
+	Kind string `json:"kind,omitempty"`
+	// Only read when parsing SARIF logs
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Fixes               []sarifFix         `json:"fixes,omitempty"`
+	Properties          *sarifProperties   `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	// Absent status meaning the suppression is accepted
+	Status string `json:"status,omitempty"`
+}
+
+type sarifProperties struct {
+	Tags     []string `json:"tags,omitempty"`
+	Category string   `json:"category,omitempty"`
+}
+
+type sarifFix struct {
+	Description     *sarifMessage         `json:"description,omitempty"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion          `json:"deletedRegion"`
+	InsertedContent sarifArtifactContent `json:"insertedContent"`
+}
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+	// Only read when parsing SARIF logs, identifier of a message string of the rule or of its tool component, used
+	// along with arguments when there is no text
+	ID        string   `json:"id,omitempty"`
+	Arguments []string `json:"arguments,omitempty"`
+}
+
+type sarifRuleReference struct {
+	ID            string                       `json:"id,omitempty"`
+	Index         *int                         `json:"index,omitempty"`
+	ToolComponent *sarifToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+type sarifToolComponentReference struct {
+	Name  string `json:"name,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// Map normalized finding levels (see validateFindings) to SARIF result levels
+func sarifLevel(level string) string {
+	switch level {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	case "notice":
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+func sarifRegionFromFinding(f *Finding) *sarifRegion {
+	// SARIF lines and columns are 1-based, a zero line means the finding is not tied to a region
+	if f.StartLine <= 0 {
+		return nil
+	}
+
+	region := sarifRegion{
+		StartLine:   f.StartLine,
+		EndLine:     0,
+		StartColumn: 0,
+		EndColumn:   0,
+	}
+
+	if f.EndLine > f.StartLine {
+		region.EndLine = f.EndLine
+	}
+
+	if f.StartCol > 0 {
+		region.StartColumn = f.StartCol
+	}
+
+	if f.EndCol > f.StartCol {
+		region.EndColumn = f.EndCol
+	}
+
+	return &region
+}
+
+func sarifFixesFromFinding(f *Finding) []sarifFix {
+	if f.Fix == nil || len(f.Fix.Replacements) == 0 {
+		return nil
+	}
+
+	fix := sarifFix{
+		Description:     nil,
+		ArtifactChanges: []sarifArtifactChange{},
+	}
+
+	if f.Fix.Description != "" {
+		fix.Description = &sarifMessage{Text: f.Fix.Description, ID: "", Arguments: nil}
+	}
+
+	// Group replacements by file, keeping order of first appearance
+	changeIndexes := map[string]int{}
+
+	for _, replacement := range f.Fix.Replacements {
+		changeIndex, ok := changeIndexes[replacement.FilePath]
+		if !ok {
+			changeIndex = len(fix.ArtifactChanges)
+			changeIndexes[replacement.FilePath] = changeIndex
+
+			fix.ArtifactChanges = append(fix.ArtifactChanges, sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: replacement.FilePath},
+				Replacements:     []sarifReplacement{},
+			})
+		}
+
+		change := &fix.ArtifactChanges[changeIndex]
+		change.Replacements = append(change.Replacements, sarifReplacement{
+			// Replacements end column is exclusive, as SARIF one
+			DeletedRegion: sarifRegion{
+				StartLine:   replacement.StartLine,
+				EndLine:     replacement.EndLine,
+				StartColumn: replacement.StartCol,
+				EndColumn:   replacement.EndCol,
+			},
+			InsertedContent: sarifArtifactContent{Text: replacement.Text},
+		})
+	}
+
+	return []sarifFix{fix}
+}
+
+func sarifLogFromFindings(findings []*Finding) sarifLog {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{},
+	}
+
+	// Keep runs and rules in order of first appearance so that output is deterministic
+	runIndexes := map[string]int{}
+	ruleIndexes := map[string]map[string]int{}
+
+	for _, finding := range findings {
+		runIndex, ok := runIndexes[finding.ToolName]
+		if !ok {
+			runIndex = len(log.Runs)
+			runIndexes[finding.ToolName] = runIndex
+			ruleIndexes[finding.ToolName] = map[string]int{}
+
+			log.Runs = append(log.Runs, sarifRun{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:                 finding.ToolName,
+						Rules:                []sarifRule{},
+						GlobalMessageStrings: nil,
+					},
+					Extensions: nil,
+				},
+				Results: []sarifResult{},
+			})
+		}
+
+		run := &log.Runs[runIndex]
+
+		ruleIndex, ok := ruleIndexes[finding.ToolName][finding.RuleID]
+		if !ok {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndexes[finding.ToolName][finding.RuleID] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: finding.RuleID, HelpURI: finding.HelpURI})
+		}
+
+		var properties *sarifProperties
+		if len(finding.Tags) > 0 || finding.Category != "" {
+			properties = &sarifProperties{Tags: finding.Tags, Category: finding.Category}
+		}
+
+		var partialFingerprints map[string]string
+		if finding.Fingerprint != "" {
+			partialFingerprints = map[string]string{sarifFingerprintName: finding.Fingerprint}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:       finding.RuleID,
+			RuleIndex:    &ruleIndex,
+			Rule:         nil,
+			Kind:         "",
+			Suppressions: nil,
+			Level:        sarifLevel(finding.Level),
+			Message: sarifMessage{
+				Text:      finding.Message,
+				ID:        "",
+				Arguments: nil,
+			},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI: finding.FilePath,
+						},
+						Region: sarifRegionFromFinding(finding),
+					},
+				},
+			},
+			PartialFingerprints: partialFingerprints,
+			Fixes:               sarifFixesFromFinding(finding),
+			Properties:          properties,
+		})
+	}
+
+	return log
+}
+
+func printFindingsSarif(w io.Writer, findings []*Finding) error {
+	output, err := json.MarshalIndent(sarifLogFromFindings(findings), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling findings to SARIF: %w", err)
+	}
+
+	fmt.Fprintln(w, string(output))
+
+	return nil
+}
+
+// Map SARIF result levels to finding levels
+func levelFromSarif(level string) string {
+	switch level {
+	case "note":
+		return "notice"
+	case "none":
+		return "debug"
+	default:
+		return level
+	}
+}
+
+// Level of a SARIF result, which defaults to the rule one for failures, or to `none` for other kinds of results, see
+// section 3.27.10 of SARIF specification.
+func sarifResultLevel(result *sarifResult, rule *sarifRule) string {
+	if result.Level != "" {
+		return result.Level
+	}
+
+	if result.Kind != "" && result.Kind != "fail" {
+		return "none"
+	}
+
+	if rule != nil && rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != "" {
+		return rule.DefaultConfiguration.Level
+	}
+
+	return "warning"
+}
+
+// Whether SARIF result is to be reported. Results that passed or do not apply are not findings, and neither are results
+// which have an accepted suppression, see sections 3.27.9 and 3.27.23 of SARIF specification.
+func sarifResultReported(result *sarifResult) bool {
+	switch result.Kind {
+	case "pass", "notApplicable", "informational":
+		return false
+	}
+
+	return !slices.ContainsFunc(result.Suppressions, func(suppression sarifSuppression) bool {
+		return suppression.Status == "" || suppression.Status == "accepted"
+	})
+}
+
+// Path of a SARIF artifact URI, which can be relative or a file URI
+func pathFromSarifURI(uri string) string {
+	if !strings.HasPrefix(uri, "file://") {
+		return uri
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return strings.TrimPrefix(uri, "file://")
+	}
+
+	return u.Path
+}
+
+func fixFromSarif(fixes []sarifFix) *Fix {
+	// Fixes are alternatives, only the first one is used
+	if len(fixes) == 0 {
+		return nil
+	}
+
+	fix := Fix{
+		Description:  "",
+		Replacements: []Replacement{},
+	}
+
+	if fixes[0].Description != nil {
+		fix.Description = fixes[0].Description.Text
+	}
+
+	for _, change := range fixes[0].ArtifactChanges {
+		for _, replacement := range change.Replacements {
+			fix.Replacements = append(fix.Replacements, Replacement{
+				FilePath:  pathFromSarifURI(change.ArtifactLocation.URI),
+				StartLine: replacement.DeletedRegion.StartLine,
+				EndLine:   max(replacement.DeletedRegion.EndLine, replacement.DeletedRegion.StartLine),
+				StartCol:  replacement.DeletedRegion.StartColumn,
+				EndCol:    replacement.DeletedRegion.EndColumn,
+				Text:      replacement.InsertedContent.Text,
+				FileHash:  "",
+			})
+		}
+	}
+
+	return &fix
+}
+
+// Tool component a rule reference points to, which is the driver unless an extension is referenced.
+func (t *sarifTool) component(reference *sarifToolComponentReference) *sarifDriver {
+	if reference == nil {
+		return &t.Driver
+	}
+
+	if reference.Index != nil {
+		if *reference.Index < 0 || *reference.Index >= len(t.Extensions) {
+			return nil
+		}
+
+		return &t.Extensions[*reference.Index]
+	}
+
+	for i := range t.Extensions {
+		if t.Extensions[i].Name == reference.Name {
+			return &t.Extensions[i]
+		}
+	}
+
+	if t.Driver.Name == reference.Name {
+		return &t.Driver
+	}
+
+	return nil
+}
+
+// Rule a result refers to along with the tool component defining it, by index or by ID, in driver rules or in
+// extensions ones, see section 3.27.5 to 3.27.7 of SARIF specification.
+func (t *sarifTool) resultRule(result *sarifResult) (*sarifRule, *sarifDriver) {
+	ruleID := result.RuleID
+	ruleIndex := result.RuleIndex
+	components := []*sarifDriver{&t.Driver}
+
+	for i := range t.Extensions {
+		components = append(components, &t.Extensions[i])
+	}
+
+	if result.Rule != nil {
+		if ruleID == "" {
+			ruleID = result.Rule.ID
+		}
+
+		if result.Rule.Index != nil {
+			ruleIndex = result.Rule.Index
+		}
+
+		if result.Rule.ToolComponent != nil {
+			component := t.component(result.Rule.ToolComponent)
+			if component == nil {
+				return nil, nil
+			}
+
+			components = []*sarifDriver{component}
+		}
+	}
+
+	if ruleIndex != nil && *ruleIndex >= 0 && *ruleIndex < len(components[0].Rules) {
+		rule := &components[0].Rules[*ruleIndex]
+		if ruleID == "" || rule.ID == ruleID {
+			return rule, components[0]
+		}
+	}
+
+	if ruleID == "" {
+		return nil, nil
+	}
+
+	for _, component := range components {
+		for i := range component.Rules {
+			if component.Rules[i].ID == ruleID {
+				return &component.Rules[i], component
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Replace `{n}` placeholders of a message string with arguments, `{{` and `}}` being escaped braces, see section 3.11.5
+// of SARIF specification.
+func formatSarifMessage(text string, arguments []string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if (c == '{' || c == '}') && i+1 < len(text) && text[i+1] == c {
+			builder.WriteByte(c)
+
+			i++
+
+			continue
+		}
+
+		if c == '{' {
+			end := strings.IndexByte(text[i:], '}')
+			if end != -1 {
+				index, err := strconv.Atoi(text[i+1 : i+end])
+				if err == nil && index >= 0 && index < len(arguments) {
+					builder.WriteString(arguments[index])
+
+					i += end
+
+					continue
+				}
+			}
+		}
+
+		builder.WriteByte(c)
+	}
+
+	return builder.String()
+}
+
+// Text of a result message, which is either set or is a message string of its rule or of the tool component defining
+// it, see section 3.11.7 of SARIF specification.
+func sarifResultMessage(message sarifMessage, rule *sarifRule, component *sarifDriver) string {
+	if message.Text != "" {
+		if len(message.Arguments) == 0 {
+			return message.Text
+		}
+
+		return formatSarifMessage(message.Text, message.Arguments)
+	}
+
+	if message.ID == "" {
+		return ""
+	}
+
+	if rule != nil {
+		if messageString, ok := rule.MessageStrings[message.ID]; ok {
+			return formatSarifMessage(messageString.Text, message.Arguments)
+		}
+	}
+
+	if component != nil {
+		if messageString, ok := component.GlobalMessageStrings[message.ID]; ok {
+			return formatSarifMessage(messageString.Text, message.Arguments)
+		}
+	}
+
+	return ""
+}
+
+// Parse findings of a SARIF result, one per location. Tool name and category can be overridden using mappings, other
+// mappings are ignored.
+func findingsFromSarifResult(tool *sarifTool, result *sarifResult, mappings JSONToFindingsMappings) []Finding {
+	toolName := tool.Driver.Name
+	if mappings.ToolName.OverrideValue != "" {
+		toolName = mappings.ToolName.OverrideValue
+	}
+
+	rule, component := tool.resultRule(result)
+
+	finding := Finding{
+		ToolName:    toolName,
+		RuleID:      result.RuleID,
+		Level:       levelFromSarif(sarifResultLevel(result, rule)),
+		FilePath:    "",
+		StartLine:   0,
+		EndLine:     0,
+		StartCol:    0,
+		EndCol:      0,
+		Message:     sarifResultMessage(result.Message, rule, component),
+		Fingerprint: "",
+		HelpURI:     "",
+		Tags:        nil,
+		Category:    mappings.Category.OverrideValue,
+		Fix:         fixFromSarif(result.Fixes),
+	}
+
+	if rule != nil {
+		finding.RuleID = rule.ID
+		finding.HelpURI = rule.HelpURI
+
+		if rule.Properties != nil {
+			finding.Tags = append(finding.Tags, rule.Properties.Tags...)
+		}
+	}
+
+	if finding.RuleID == "" && result.Rule != nil {
+		finding.RuleID = result.Rule.ID
+	}
+
+	// Message strings may be defined in a part of the log that is not modeled, rule is the best description left
+	if finding.Message == "" {
+		finding.Message = finding.RuleID
+	}
+
+	if result.Properties != nil {
+		for _, tag := range result.Properties.Tags {
+			if !slices.Contains(finding.Tags, tag) {
+				finding.Tags = append(finding.Tags, tag)
+			}
+		}
+
+		if finding.Category == "" {
+			finding.Category = result.Properties.Category
+		}
+	}
+
+	// Results that are not tied to an artifact, such as repository-level ones, are still reported
+	if len(result.Locations) == 0 {
+		finding.FilePath = sarifRepositoryPath
+
+		return []Finding{finding}
+	}
+
+	findings := make([]Finding, 0, len(result.Locations))
+
+	for _, location := range result.Locations {
+		locatedFinding := finding
+		// Each finding owns its fix, as fixes are updated along with their finding
+		locatedFinding.Fix = fixFromSarif(result.Fixes)
+
+		locatedFinding.FilePath = pathFromSarifURI(location.PhysicalLocation.ArtifactLocation.URI)
+		if locatedFinding.FilePath == "" {
+			locatedFinding.FilePath = sarifRepositoryPath
+		}
+
+		if region := location.PhysicalLocation.Region; region != nil {
+			locatedFinding.StartLine = region.StartLine
+			locatedFinding.EndLine = region.EndLine
+			locatedFinding.StartCol = region.StartColumn
+			locatedFinding.EndCol = region.EndColumn
+		}
+
+		findings = append(findings, locatedFinding)
+	}
+
+	return findings
+}
+
+// Parse findings from a SARIF log as it is written, see findingsFromSarifResult. Results are decoded one at a time, the
+// tool of their run being needed to resolve their rule: results written before the tool in a run are held until it is
+// read.
+func decodeSarifFindings(r io.Reader, mappings JSONToFindingsMappings, emit func(Finding)) error {
+	decoder := json.NewDecoder(r)
+
+	// Output may be empty if there is no finding
+	token, err := decoder.Token()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error unmarshalling SARIF: %w", err)
+	}
+
+	if token != json.Delim('{') {
+		return fmt.Errorf("error unmarshalling SARIF: %w", ErrJSONNotMap)
+	}
+
+	return decodeSarifObject(decoder, func(key string) error {
+		if key != "runs" {
+			return skipValue(decoder)
+		}
+
+		return decodeSarifArray(decoder, func() error {
+			return decodeSarifRun(decoder, mappings, emit)
+		})
+	})
+}
+
+// Decode a run of a SARIF log, see decodeSarifFindings.
+func decodeSarifRun(decoder *json.Decoder, mappings JSONToFindingsMappings, emit func(Finding)) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling SARIF: %w", err)
+	}
+
+	if token != json.Delim('{') {
+		return fmt.Errorf("error unmarshalling SARIF run: %w", ErrJSONNotMap)
+	}
+
+	var tool sarifTool
+
+	toolRead := false
+	pending := []sarifResult{}
+
+	emitResult := func(result *sarifResult) {
+		if !sarifResultReported(result) {
+			return
+		}
+
+		for _, finding := range findingsFromSarifResult(&tool, result, mappings) {
+			emit(finding)
+		}
+	}
+
+	err = decodeSarifObject(decoder, func(key string) error {
+		switch key {
+		case "tool":
+			err := decoder.Decode(&tool)
+			if err != nil {
+				return fmt.Errorf("error unmarshalling SARIF tool: %w", err)
+			}
+
+			toolRead = true
+
+			for i := range pending {
+				emitResult(&pending[i])
+			}
+
+			pending = nil
+
+			return nil
+		case "results":
+			return decodeSarifArray(decoder, func() error {
+				var result sarifResult
+
+				err := decoder.Decode(&result)
+				if err != nil {
+					return fmt.Errorf("error unmarshalling SARIF result: %w", err)
+				}
+
+				if toolRead {
+					emitResult(&result)
+				} else {
+					pending = append(pending, result)
+				}
+
+				return nil
+			})
+		}
+
+		return skipValue(decoder)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Tool is mandatory, results are still reported with what is known of their rule otherwise
+	for i := range pending {
+		emitResult(&pending[i])
+	}
+
+	return nil
+}
+
+// Consume the keys of the object being decoded, which opening token is consumed, decodeValue consuming their value.
+func decodeSarifObject(decoder *json.Decoder, decodeValue func(key string) error) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling SARIF: %w", err)
+		}
+
+		key, _ := token.(string)
+
+		err = decodeValue(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Closing delimiter
+	_, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling SARIF: %w", err)
+	}
+
+	return nil
+}
+
+// Consume the next value, which is either null or an array which elements are consumed by decodeElement.
+func decodeSarifArray(decoder *json.Decoder, decodeElement func() error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling SARIF: %w", err)
+	}
+
+	if token == nil {
+		return nil
+	}
+
+	if token != json.Delim('[') {
+		return fmt.Errorf("error unmarshalling SARIF: %w", ErrJSONNotArray)
+	}
+
+	for decoder.More() {
+		err := decodeElement()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Closing delimiter
+	_, err = decoder.Token()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling SARIF: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,379 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Parse findings from a whole SARIF log.
+func findingsFromSarif(str string, mappings JSONToFindingsMappings) ([]Finding, error) {
+	findings := []Finding{}
+
+	err := decodeSarifFindings(strings.NewReader(str), mappings, func(finding Finding) {
+		findings = append(findings, finding)
+	})
+
+	return findings, err
+}
+
+func TestFindingsFromSarif(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		log      string
+		mappings JSONToFindingsMappings
+		want     []Finding
+	}{
+		{
+			name: "rule by ID",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [
+					{"id": "first", "helpUri": "https://example.com/first"},
+					{"id": "second", "defaultConfiguration": {"level": "note"}, "properties": {"tags": ["security"]}}
+				]}},
+				"results": [{
+					"ruleId": "second",
+					"message": {"text": "message"},
+					"locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///src/main.go"}, "region": {"startLine": 3, "startColumn": 2}}}]
+				}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "second", Level: "notice", FilePath: "/src/main.go", StartLine: 3, StartCol: 2, Message: "message", Tags: []string{"security"}},
+			},
+		},
+		{
+			name: "rule by index",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "first"}, {"id": "second"}]}},
+				"results": [{"ruleIndex": 1, "message": {"text": "message"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "second", Level: "warning", FilePath: "main.go", Message: "message"},
+			},
+		},
+		{
+			name: "absent rule index is not the first rule",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "first", "helpUri": "https://example.com/first"}]}},
+				"results": [{"ruleId": "unknown", "message": {"text": "message"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "unknown", Level: "warning", FilePath: "main.go", Message: "message"},
+			},
+		},
+		{
+			name: "rule index of -1 is unknown",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "first"}]}},
+				"results": [{"ruleId": "other", "ruleIndex": -1, "message": {"text": "message"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "other", Level: "warning", FilePath: "main.go", Message: "message"},
+			},
+		},
+		{
+			name: "rule of an extension by ID",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": []}, "extensions": [{"name": "plugin", "rules": [{"id": "plugin-rule", "helpUri": "https://example.com/plugin"}]}]},
+				"results": [{"ruleId": "plugin-rule", "level": "error", "message": {"text": "message"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "plugin-rule", Level: "error", FilePath: "main.go", Message: "message", HelpURI: "https://example.com/plugin"},
+			},
+		},
+		{
+			name: "rule of an extension by reference",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "driver-rule"}]}, "extensions": [{"name": "plugin", "rules": [{"id": "plugin-rule", "defaultConfiguration": {"level": "error"}}]}]},
+				"results": [{"rule": {"index": 0, "toolComponent": {"index": 0}}, "message": {"text": "message"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "plugin-rule", Level: "error", FilePath: "main.go", Message: "message"},
+			},
+		},
+		{
+			name: "message string of rule",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "rule", "messageStrings": {"default": {"text": "Variable '{0}' is unused, {{ignored}} by {1}"}}}]}},
+				"results": [{"ruleId": "rule", "message": {"id": "default", "arguments": ["x", "nobody"]}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: "main.go", Message: "Variable 'x' is unused, {ignored} by nobody"},
+			},
+		},
+		{
+			name: "global message string of tool component",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "rule"}], "globalMessageStrings": {"shared": {"text": "Shared {0}"}}}},
+				"results": [{"ruleId": "rule", "message": {"id": "shared", "arguments": ["message"]}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: "main.go", Message: "Shared message"},
+			},
+		},
+		{
+			name: "unknown message string",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": []}},
+				"results": [{"ruleId": "rule", "message": {"id": "unknown"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: "main.go", Message: "rule"},
+			},
+		},
+		{
+			name: "result without location",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": []}},
+				"results": [{"ruleId": "rule", "level": "none", "message": {"text": "message"}}]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "debug", FilePath: sarifRepositoryPath, Message: "message"},
+			},
+		},
+		{
+			name: "one finding per location, with overridden tool name",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": []}},
+				"results": [{"ruleId": "rule", "message": {"text": "message"}, "locations": [
+					{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}},
+					{"physicalLocation": {"artifactLocation": {"uri": "b.go"}}}
+				]}]
+			}]}`,
+			mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "overridden"}},
+			want: []Finding{
+				{ToolName: "overridden", RuleID: "rule", Level: "warning", FilePath: "a.go", Message: "message"},
+				{ToolName: "overridden", RuleID: "rule", Level: "warning", FilePath: "b.go", Message: "message"},
+			},
+		},
+		{
+			name: "results that are not failures",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "rule", "defaultConfiguration": {"level": "error"}}]}},
+				"results": [
+					{"ruleId": "rule", "kind": "pass", "message": {"text": "passed"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]},
+					{"ruleId": "rule", "kind": "notApplicable", "message": {"text": "not applicable"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]},
+					{"ruleId": "rule", "kind": "informational", "message": {"text": "informational"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]},
+					{"ruleId": "rule", "kind": "review", "message": {"text": "to review"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]},
+					{"ruleId": "rule", "kind": "open", "level": "note", "message": {"text": "open"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]},
+					{"ruleId": "rule", "kind": "fail", "message": {"text": "failed"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}}}]}
+				]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "debug", FilePath: "a.go", Message: "to review"},
+				{ToolName: "tool", RuleID: "rule", Level: "notice", FilePath: "a.go", Message: "open"},
+				{ToolName: "tool", RuleID: "rule", Level: "error", FilePath: "a.go", Message: "failed"},
+			},
+		},
+		{
+			name: "suppressed results",
+			log: `{"runs": [{
+				"tool": {"driver": {"name": "tool", "rules": []}},
+				"results": [
+					{"ruleId": "rule", "message": {"text": "accepted"}, "suppressions": [{"kind": "inSource", "status": "accepted"}]},
+					{"ruleId": "rule", "message": {"text": "without status"}, "suppressions": [{"kind": "external"}]},
+					{"ruleId": "rule", "message": {"text": "rejected"}, "suppressions": [{"kind": "inSource", "status": "rejected"}]},
+					{"ruleId": "rule", "message": {"text": "under review"}, "suppressions": [{"kind": "inSource", "status": "underReview"}]},
+					{"ruleId": "rule", "message": {"text": "empty"}, "suppressions": []}
+				]
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: ".", Message: "rejected"},
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: ".", Message: "under review"},
+				{ToolName: "tool", RuleID: "rule", Level: "warning", FilePath: ".", Message: "empty"},
+			},
+		},
+		{
+			name: "tool after results",
+			log: `{"runs": [{
+				"results": [{"ruleId": "rule", "message": {"text": "message"}}],
+				"tool": {"driver": {"name": "tool", "rules": [{"id": "rule", "defaultConfiguration": {"level": "error"}}]}}
+			}]}`,
+			mappings: JSONToFindingsMappings{},
+			want: []Finding{
+				{ToolName: "tool", RuleID: "rule", Level: "error", FilePath: ".", Message: "message"},
+			},
+		},
+		{
+			name:     "empty log",
+			log:      "",
+			mappings: JSONToFindingsMappings{},
+			want:     []Finding{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := findingsFromSarif(test.log, test.mappings)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findingsFromSarif() =\n%+v\nwant\n%+v", got, test.want)
+			}
+
+			// Findings must be reportable as is
+			err = NormalizeFindings(got)
+			if err != nil {
+				t.Errorf("NormalizeFindings() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFindingsFromSarifFixPerLocation(t *testing.T) {
+	t.Parallel()
+
+	got, err := findingsFromSarif(`{"runs": [{
+		"tool": {"driver": {"name": "tool", "rules": []}},
+		"results": [{
+			"ruleId": "rule",
+			"message": {"text": "message"},
+			"locations": [
+				{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 1}}},
+				{"physicalLocation": {"artifactLocation": {"uri": "b.go"}, "region": {"startLine": 2}}}
+			],
+			"fixes": [{"artifactChanges": [{
+				"artifactLocation": {"uri": "a.go"},
+				"replacements": [{"deletedRegion": {"startLine": 1, "startColumn": 1, "endColumn": 2}, "insertedContent": {"text": "x"}}]
+			}]}]
+		}]
+	}]}`, JSONToFindingsMappings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0].Fix == nil || got[1].Fix == nil {
+		t.Fatalf("findingsFromSarif() = %+v, want 2 findings with a fix", got)
+	}
+
+	got[0].Fix.Replacements[0].FileHash = "hash"
+
+	if got[1].Fix.Replacements[0].FileHash != "" {
+		t.Error("updating fix of a finding changed the one of another location")
+	}
+}
+
+func TestSarifRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		{
+			ToolName:  "tool",
+			RuleID:    "rule",
+			Level:     "error",
+			FilePath:  "main.go",
+			StartLine: 3,
+			EndLine:   4,
+			StartCol:  2,
+			EndCol:    5,
+			Message:   "message",
+			HelpURI:   "https://example.com/rule",
+			Tags:      []string{"security"},
+			Fix: &Fix{
+				Description:  "fix",
+				Replacements: []Replacement{{FilePath: "main.go", StartLine: 3, EndLine: 3, StartCol: 2, EndCol: 5, Text: "text"}},
+			},
+		},
+		{ToolName: "tool", RuleID: "other", Level: "notice", FilePath: "other.go", Message: "other message"},
+	}
+
+	pfindings := make([]*Finding, len(findings))
+	for i := range findings {
+		pfindings[i] = &findings[i]
+	}
+
+	var builder strings.Builder
+
+	err := printFindingsSarif(&builder, pfindings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := findingsFromSarif(builder.String(), JSONToFindingsMappings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, findings) {
+		t.Errorf("findings read back =\n%+v\nwant\n%+v", got, findings)
+	}
+}
+
+func TestSarifLogFromFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []*Finding{
+		{ToolName: "first", RuleID: "a", Level: "error", FilePath: "main.go", StartLine: 3, EndLine: 3, StartCol: 2, EndCol: 1, Message: "m"},
+		{ToolName: "second", RuleID: "b", Level: "notice", FilePath: "go.mod", Message: "m"},
+		{ToolName: "first", RuleID: "c", Level: "debug", FilePath: "main.go", StartLine: 4, EndLine: 6, Message: "m", Fingerprint: "fp"},
+		{ToolName: "first", RuleID: "a", Level: "warning", FilePath: "other.go", StartLine: 1, Message: "m"},
+	}
+
+	log := sarifLogFromFindings(findings)
+
+	if log.Version != sarifVersion || log.Schema != sarifSchema {
+		t.Errorf("log version = %s and schema = %s, want %s and %s", log.Version, log.Schema, sarifVersion, sarifSchema)
+	}
+
+	if len(log.Runs) != 2 || log.Runs[0].Tool.Driver.Name != "first" || log.Runs[1].Tool.Driver.Name != "second" {
+		t.Fatalf("runs = %+v, want one per tool in order of appearance", log.Runs)
+	}
+
+	run := log.Runs[0]
+
+	rules := []string{}
+	for _, rule := range run.Tool.Driver.Rules {
+		rules = append(rules, rule.ID)
+	}
+
+	if !reflect.DeepEqual(rules, []string{"a", "c"}) {
+		t.Errorf("rules = %v, want [a c]", rules)
+	}
+
+	tests := []struct {
+		result    sarifResult
+		ruleIndex int
+		level     string
+		region    *sarifRegion
+	}{
+		{result: run.Results[0], ruleIndex: 0, level: "error", region: &sarifRegion{StartLine: 3, StartColumn: 2}},
+		{result: run.Results[1], ruleIndex: 1, level: "none", region: &sarifRegion{StartLine: 4, EndLine: 6}},
+		{result: run.Results[2], ruleIndex: 0, level: "warning", region: &sarifRegion{StartLine: 1}},
+		{result: log.Runs[1].Results[0], ruleIndex: 0, level: "note", region: nil},
+	}
+
+	for i, test := range tests {
+		if *test.result.RuleIndex != test.ruleIndex || test.result.Level != test.level {
+			t.Errorf("result %d rule index = %d and level = %s, want %d and %s", i, *test.result.RuleIndex, test.result.Level, test.ruleIndex, test.level)
+		}
+
+		if region := test.result.Locations[0].PhysicalLocation.Region; !reflect.DeepEqual(region, test.region) {
+			t.Errorf("result %d region = %+v, want %+v", i, region, test.region)
+		}
+	}
+
+	if fingerprints := run.Results[1].PartialFingerprints; fingerprints[sarifFingerprintName] != "fp" {
+		t.Errorf("partial fingerprints = %v, want fingerprint under %s", fingerprints, sarifFingerprintName)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DecodeFindings decodes findings from given reader as it is written, calling emit for each of them, so that memory is
+// bounded by the size of a single element: elements of JSON arrays, arrays found under BaseArrayKey and JSON streams,
+// SARIF results, Checkstyle errors and JUnit test cases are decoded one at a time, and regexes are matched against a
+// window of lines, see decodeRegexFindings.
+func DecodeFindings(r io.Reader, jsonInfo JSONInfos, emit func(Finding)) error {
+	plan, err := CompileJSONInfos(jsonInfo)
+	if err != nil {
+		return fmt.Errorf("error compiling mappings: %w", err)
+	}
+
+	return plan.Decode(r, emit)
+}
+
+func decodeJSONFindings(r io.Reader, plan *FindingsPlan, emit func(Finding)) error {
+	jsonInfo := plan.jsonInfo
+	decoder := json.NewDecoder(r)
+
+	decodeElement := func() error {
+		var item any
+
+		err := decoder.Decode(&item)
+		if err != nil {
+			return fmt.Errorf("error unmarshalling json: %w", err)
+		}
+
+		m, ok := item.(map[string]any)
+		if !ok {
+			return ErrJSONNotArray
+		}
+
+		return emitObjectFindings(m, plan, emit)
+	}
+
+	switch jsonInfo.Type {
+	case "stream":
+		for decoder.More() {
+			err := decodeElement()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "object":
+		if !decoder.More() {
+			return nil
+		}
+
+		return decodeElement()
+	}
+
+	// Output may be empty if there is no finding
+	token, err := decoder.Token()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error unmarshalling json: %w", err)
+	}
+
+	if jsonInfo.Mappings.BaseArrayKey == "" && token != json.Delim('[') {
+		return ErrJSONNotArray
+	}
+
+	if jsonInfo.Mappings.BaseArrayKey != "" {
+		if token != json.Delim('{') {
+			return fmt.Errorf("json does not contain key %s: %w", jsonInfo.Mappings.BaseArrayKey, ErrJSONNoSuchKey)
+		}
+
+		err = seekKey(decoder, jsonInfo.Mappings.BaseArrayKey)
+		if err != nil {
+			return err
+		}
+
+		token, err = decoder.Token()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling json: %w", err)
+		}
+
+		if token != json.Delim('[') {
+			return ErrJSONNotArray
+		}
+	}
+
+	for decoder.More() {
+		err := decodeElement()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parse findings from a single object, calling emit for each of them.
+func emitObjectFindings(m map[string]any, plan *FindingsPlan, emit func(Finding)) error {
+	findings, err := findingsFromObject(m, plan)
+	if err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		emit(finding)
+	}
+
+	return nil
+}
+
+// Consume tokens of the object being decoded up to the value of given key, skipping values of other keys.
+func seekKey(decoder *json.Decoder, key string) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling json: %w", err)
+		}
+
+		if token == key {
+			return nil
+		}
+
+		err = skipValue(decoder)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("json does not contain key %s: %w", key, ErrJSONNoSuchKey)
+}
+
+// Consume tokens of next value, without keeping them in memory.
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling json: %w", err)
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
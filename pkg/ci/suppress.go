@@ -0,0 +1,136 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// Inline suppression directive, used as `kema-ignore: <tool>/<rule-id>[, <tool>/<rule-id>...] -- <reason>` in a
+	// comment on the line of the finding or on the line above. `*` can be used as rule ID to match any rule of a tool.
+	SuppressionDirective = "kema-ignore:"
+	// Tool name of findings about invalid suppression directives
+	SuppressionToolName = "kema-ignore"
+)
+
+type suppression struct {
+	line    int
+	targets []string
+	reason  string
+}
+
+// Parse suppression directive of given line, if any.
+func parseSuppression(text string, line int) (suppression, bool) {
+	_, directive, found := strings.Cut(text, SuppressionDirective)
+	if !found {
+		return suppression{}, false
+	}
+
+	// Strip comment closers of languages using block comments, e.g. `/* ... */` or `<!-- ... -->`, before splitting the
+	// reason, as `-->` would otherwise be taken for its separator
+	for _, closer := range []string{"*/", "-->"} {
+		directive, _, _ = strings.Cut(directive, closer)
+	}
+
+	targets, reason, _ := strings.Cut(directive, "--")
+
+	return suppression{
+		line:    line,
+		targets: strings.FieldsFunc(targets, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }),
+		reason:  strings.TrimSpace(reason),
+	}, true
+}
+
+func (s suppression) matches(f *Finding) bool {
+	for _, target := range s.targets {
+		tool, rule, found := strings.Cut(target, "/")
+		if !found || tool != f.ToolName {
+			continue
+		}
+
+		if rule == "*" || rule == f.RuleID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find suppression directive matching given finding, on its line or on the line above.
+func findSuppression(f *Finding, cache fileLinesCache) (suppression, bool) {
+	if f.StartLine <= 0 {
+		return suppression{}, false
+	}
+
+	lines := cache.get(f.FilePath)
+
+	for _, line := range []int{f.StartLine, f.StartLine - 1} {
+		if line <= 0 || line > len(lines) {
+			continue
+		}
+
+		s, found := parseSuppression(lines[line-1], line)
+		if found && s.matches(f) {
+			return s, true
+		}
+	}
+
+	return suppression{}, false
+}
+
+// Split normalized findings into the ones that are to be reported and the ones that are suppressed by an inline
+// directive. Directives without a reason suppress findings as well, but yield a finding of their own, which is part of
+// returned findings to report.
+func ApplySuppressions(findings []Finding) ([]Finding, []Finding) {
+	var kept, suppressed, invalid []Finding
+
+	cache := fileLinesCache{}
+	reportedDirectives := map[string]struct{}{}
+
+	for _, finding := range findings {
+		s, found := findSuppression(&finding, cache)
+		if !found {
+			kept = append(kept, finding)
+
+			continue
+		}
+
+		suppressed = append(suppressed, finding)
+
+		directiveLocation := finding.FilePath + ":" + strconv.Itoa(s.line)
+		if _, reported := reportedDirectives[directiveLocation]; s.reason != "" || reported {
+			continue
+		}
+
+		reportedDirectives[directiveLocation] = struct{}{}
+
+		invalid = append(invalid, Finding{
+			ToolName:    SuppressionToolName,
+			RuleID:      "suppression-reason-required",
+			Level:       "error",
+			FilePath:    finding.FilePath,
+			StartLine:   s.line,
+			EndLine:     0,
+			StartCol:    0,
+			EndCol:      0,
+			Message:     "Suppression directive must give a reason, as in `" + SuppressionDirective + " <tool>/<rule-id> -- <reason>`",
+			Fingerprint: "",
+			HelpURI:     "",
+			Tags:        nil,
+			Category:    "",
+			Fix:         nil,
+		})
+	}
+
+	pinvalid := make([]*Finding, len(invalid))
+	for i := range invalid {
+		pinvalid[i] = &invalid[i]
+	}
+
+	computeFingerprints(pinvalid)
+
+	return append(kept, invalid...), suppressed
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSuppression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		text      string
+		want      suppression
+		wantFound bool
+	}{
+		{
+			name:      "go",
+			text:      "\tdb.Query(query) // kema-ignore: sast-semgrep/sql-injection -- query is a constant",
+			want:      suppression{line: 3, targets: []string{"sast-semgrep/sql-injection"}, reason: "query is a constant"},
+			wantFound: true,
+		},
+		{
+			name:      "go block comment",
+			text:      "/* kema-ignore: golangci-lint/gosec -- reviewed */",
+			want:      suppression{line: 3, targets: []string{"golangci-lint/gosec"}, reason: "reviewed"},
+			wantFound: true,
+		},
+		{
+			name:      "yaml with several targets",
+			text:      "  image: alpine # kema-ignore: hadolint/DL3007, sast-semgrep/* -- pinned by digest upstream",
+			want:      suppression{line: 3, targets: []string{"hadolint/DL3007", "sast-semgrep/*"}, reason: "pinned by digest upstream"},
+			wantFound: true,
+		},
+		{
+			name:      "html",
+			text:      "<!-- kema-ignore: markdownlint/MD013 -- long table row -->",
+			want:      suppression{line: 3, targets: []string{"markdownlint/MD013"}, reason: "long table row"},
+			wantFound: true,
+		},
+		{
+			name:      "html without reason",
+			text:      "<!-- kema-ignore: markdownlint/MD013 -->",
+			want:      suppression{line: 3, targets: []string{"markdownlint/MD013"}, reason: ""},
+			wantFound: true,
+		},
+		{
+			name:      "block comment without reason",
+			text:      "/* kema-ignore: golangci-lint/gosec */",
+			want:      suppression{line: 3, targets: []string{"golangci-lint/gosec"}, reason: ""},
+			wantFound: true,
+		},
+		{
+			name:      "shell",
+			text:      "echo $var # kema-ignore: shellcheck/2086 -- word splitting is intended",
+			want:      suppression{line: 3, targets: []string{"shellcheck/2086"}, reason: "word splitting is intended"},
+			wantFound: true,
+		},
+		{
+			name:      "shell without reason",
+			text:      "echo $var # kema-ignore: shellcheck/2086",
+			want:      suppression{line: 3, targets: []string{"shellcheck/2086"}, reason: ""},
+			wantFound: true,
+		},
+		{
+			name:      "no directive",
+			text:      "echo $var # shellcheck disable=SC2086",
+			want:      suppression{},
+			wantFound: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := parseSuppression(test.text, 3)
+			if found != test.wantFound {
+				t.Fatalf("parseSuppression() found = %t, want %t", found, test.wantFound)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseSuppression() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplySuppressions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "README.md")
+
+	content := "# Title\n" +
+		"<!-- kema-ignore: markdownlint/MD013 -- long table row -->\n" +
+		"| a very long row |\n" +
+		"<!-- kema-ignore: markdownlint/MD033 -->\n" +
+		"<br>\n" +
+		"plain line\n"
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finding := func(rule string, line int) Finding {
+		return Finding{ToolName: "markdownlint", RuleID: rule, Level: "error", FilePath: path, StartLine: line, Message: rule}
+	}
+
+	kept, suppressed := ApplySuppressions([]Finding{
+		finding("MD013", 3),
+		finding("MD033", 5),
+		finding("MD013", 6),
+		finding("MD009", 3),
+	})
+
+	if len(suppressed) != 2 {
+		t.Errorf("got %d suppressed findings, want 2: %v", len(suppressed), suppressed)
+	}
+
+	rules := []string{}
+	for _, finding := range kept {
+		rules = append(rules, finding.ToolName+"/"+finding.RuleID)
+	}
+
+	// Directive without reason suppresses finding, but is reported itself
+	want := []string{"markdownlint/MD013", "markdownlint/MD009", SuppressionToolName + "/suppression-reason-required"}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("kept findings = %v, want %v", rules, want)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+// Tool name of findings about suppressions file entries
+const SuppressionsFileToolName = "kema-suppressions"
+
+var (
+	ErrSuppressionMissingField = fmt.Errorf("suppression is missing a mandatory field")
+	ErrSuppressionInvalidField = fmt.Errorf("suppression has an invalid field")
+)
+
+// SuppressionsFile holds time-boxed, justified exceptions for findings, defined at repository level.
+type SuppressionsFile struct {
+	Path         string
+	Suppressions []SuppressionEntry
+}
+
+type SuppressionEntry struct {
+	// Tool name of findings to suppress
+	Tool string `yaml:"tool"`
+	// Rule ID of findings to suppress, `*` matches any rule
+	Rule string `yaml:"rule"`
+	// Glob of paths of findings to suppress, `**` matching any number of directories, empty value matches any path
+	Path string `yaml:"path"`
+	// Fingerprint of finding to suppress, empty value matches any finding
+	Fingerprint string `yaml:"fingerprint"`
+	// Justification of the suppression
+	Reason string `yaml:"reason"`
+	// Last day the suppression is effective, as YYYY-MM-DD in UTC
+	Expires string `yaml:"expires"`
+
+	line       int
+	pathGlob   glob.Glob
+	expiryDate time.Time
+}
+
+type suppressionsFileContent struct {
+	Suppressions []yaml.Node `yaml:"suppressions"`
+}
+
+// Read suppressions file at given path, a missing file meaning there is no suppression.
+func ReadSuppressionsFile(path string) (*SuppressionsFile, error) {
+	suppressionsFile := SuppressionsFile{
+		Path:         path,
+		Suppressions: []SuppressionEntry{},
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &suppressionsFile, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading suppressions file: %w", err)
+	}
+
+	var fileContent suppressionsFileContent
+
+	err = yaml.Unmarshal(content, &fileContent)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling suppressions file: %w", err)
+	}
+
+	// Entries are decoded one by one to keep track of their line, so that findings can point to them
+	for _, node := range fileContent.Suppressions {
+		var entry SuppressionEntry
+
+		err := node.Decode(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding suppression at line %d: %w", node.Line, err)
+		}
+
+		entry.line = node.Line
+
+		err = entry.compile()
+		if err != nil {
+			return nil, fmt.Errorf("suppression at %s:%d: %w", path, node.Line, err)
+		}
+
+		suppressionsFile.Suppressions = append(suppressionsFile.Suppressions, entry)
+	}
+
+	return &suppressionsFile, nil
+}
+
+func (e *SuppressionEntry) compile() error {
+	for name, value := range map[string]string{
+		"tool":    e.Tool,
+		"rule":    e.Rule,
+		"reason":  e.Reason,
+		"expires": e.Expires,
+	} {
+		if value == "" {
+			return fmt.Errorf("%s: %w", name, ErrSuppressionMissingField)
+		}
+	}
+
+	expiryDate, err := time.ParseInLocation(time.DateOnly, e.Expires, time.UTC)
+	if err != nil {
+		return fmt.Errorf("expires %s: %w", e.Expires, ErrSuppressionInvalidField)
+	}
+
+	e.expiryDate = expiryDate
+
+	if e.Path != "" {
+		pathGlob, err := glob.Compile(e.Path, '/')
+		if err != nil {
+			return fmt.Errorf("path %s: %w", e.Path, ErrSuppressionInvalidField)
+		}
+
+		e.pathGlob = pathGlob
+	}
+
+	return nil
+}
+
+func (e *SuppressionEntry) matches(f *Finding) bool {
+	if e.Tool != f.ToolName {
+		return false
+	}
+
+	if e.Rule != "*" && e.Rule != f.RuleID {
+		return false
+	}
+
+	if e.pathGlob != nil && !e.pathGlob.Match(normalizeFingerprintPath(f.FilePath)) {
+		return false
+	}
+
+	return e.Fingerprint == "" || e.Fingerprint == f.Fingerprint
+}
+
+// Split normalized findings into the ones that are to be reported and the ones that are suppressed by an entry of the
+// file. Findings matching an expired entry are reported, along with a finding about the lapsed entry.
+func (s *SuppressionsFile) Apply(findings []Finding, now time.Time) ([]Finding, []Finding) {
+	var kept, suppressed, lapsed []Finding
+
+	reportedEntries := map[int]struct{}{}
+
+	// Suppressions are effective through the end of their last day, days being the UTC ones whatever the local time zone
+	year, month, day := now.UTC().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	for _, finding := range findings {
+		entry := s.findEntry(&finding)
+		if entry == nil {
+			kept = append(kept, finding)
+
+			continue
+		}
+
+		if !today.After(entry.expiryDate) {
+			suppressed = append(suppressed, finding)
+
+			continue
+		}
+
+		kept = append(kept, finding)
+
+		if _, reported := reportedEntries[entry.line]; reported {
+			continue
+		}
+
+		reportedEntries[entry.line] = struct{}{}
+
+		lapsed = append(lapsed, Finding{
+			ToolName:  SuppressionsFileToolName,
+			RuleID:    "suppression-expired",
+			Level:     "error",
+			FilePath:  s.Path,
+			StartLine: entry.line,
+			EndLine:   0,
+			StartCol:  0,
+			EndCol:    0,
+			Message: fmt.Sprintf(
+				"Suppression of %s/%s expired on %s, findings it matches are reported again. Fix them or renew the suppression. Reason was: %s",
+				entry.Tool,
+				entry.Rule,
+				entry.Expires,
+				entry.Reason,
+			),
+			Fingerprint: "",
+			HelpURI:     "",
+			Tags:        nil,
+			Category:    "",
+			Fix:         nil,
+		})
+	}
+
+	plapsed := make([]*Finding, len(lapsed))
+	for i := range lapsed {
+		plapsed[i] = &lapsed[i]
+	}
+
+	computeFingerprints(plapsed)
+
+	return append(kept, lapsed...), suppressed
+}
+
+func (s *SuppressionsFile) findEntry(f *Finding) *SuppressionEntry {
+	for i := range s.Suppressions {
+		if s.Suppressions[i].matches(f) {
+			return &s.Suppressions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSuppressionsFileApplyExpiry(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".suppressions.yaml")
+
+	content := `suppressions:
+  - tool: tool
+    rule: rule
+    reason: false positive
+    expires: "2025-01-10"
+`
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suppressions, err := ReadSuppressionsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		wantSuppressed bool
+	}{
+		{name: "before last day", now: time.Date(2025, 1, 9, 12, 0, 0, 0, time.UTC), wantSuppressed: true},
+		{name: "start of last day", now: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), wantSuppressed: true},
+		{name: "end of last day", now: time.Date(2025, 1, 10, 23, 59, 59, 0, time.UTC), wantSuppressed: true},
+		{name: "day after", now: time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), wantSuppressed: false},
+		{
+			name:           "local time ahead of UTC on last day",
+			now:            time.Date(2025, 1, 11, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			wantSuppressed: true,
+		},
+		{
+			name:           "local time behind UTC on day after",
+			now:            time.Date(2025, 1, 10, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*60*60)),
+			wantSuppressed: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings := []Finding{{ToolName: "tool", RuleID: "rule", Level: "error", FilePath: "file", Message: "message"}}
+
+			kept, suppressed := suppressions.Apply(findings, test.now)
+
+			if got := len(suppressed) == 1; got != test.wantSuppressed {
+				t.Errorf("suppressed = %t, want %t", got, test.wantSuppressed)
+			}
+
+			// Expired entries are reported along with the findings they match
+			wantKept := 0
+			if !test.wantSuppressed {
+				wantKept = 2
+			}
+
+			if len(kept) != wantKept {
+				t.Errorf("kept %d findings, want %d", len(kept), wantKept)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"maps"
+	"strings"
+)
+
+// Suffix of keys which array elements are to be fanned out into several findings
+const KeyWildcard = "[*]"
+
+// Keys of all mappings, including suffixes ones, in order of definition.
+func mappingsKeys(mappings JSONToFindingsMappings) []string {
+	var keys []string
+
+	addKeys := func(mapping *JSONMappingInfo) {
+		for ; mapping != nil; mapping = mapping.Suffix {
+			if mapping.OverrideValue == "" && mapping.Key != "" {
+				keys = append(keys, mapping.Key)
+			}
+		}
+	}
+
+	for _, mapping := range []JSONMappingInfo{
+		mappings.ToolName,
+		mappings.RuleID,
+		mappings.Level,
+		mappings.FilePath,
+		mappings.StartLine,
+		mappings.EndLine,
+		mappings.StartCol,
+		mappings.EndCol,
+		mappings.Message,
+		mappings.HelpURI,
+		mappings.Tags,
+		mappings.Category,
+		mappings.Fix.Description,
+	} {
+		addKeys(&mapping)
+	}
+
+	// Replacements keys are relative to replacements array elements if any
+	if mappings.Fix.BaseArrayKey != "" {
+		return append(keys, mappings.Fix.BaseArrayKey)
+	}
+
+	for _, mapping := range []JSONMappingInfo{
+		mappings.Fix.FilePath,
+		mappings.Fix.StartLine,
+		mappings.Fix.EndLine,
+		mappings.Fix.StartCol,
+		mappings.Fix.EndCol,
+		mappings.Fix.Text,
+	} {
+		addKeys(&mapping)
+	}
+
+	return keys
+}
+
+// First array path to fan out, that is the part of a key preceding a wildcard which was not already resolved.
+func nextWildcard(keys []string, resolved map[string]struct{}) (string, bool) {
+	for _, key := range keys {
+		for i := strings.Index(key, KeyWildcard); i != -1; {
+			prefix := key[:i]
+			if _, ok := resolved[prefix]; !ok {
+				return prefix, true
+			}
+
+			next := strings.Index(key[i+len(KeyWildcard):], KeyWildcard)
+			if next == -1 {
+				break
+			}
+
+			i += len(KeyWildcard) + next
+		}
+	}
+
+	return "", false
+}
+
+// Fan out given object into one view per element of each array referenced using a wildcard, in which the array is
+// replaced by the element. Several arrays yield the cartesian product of their elements. Empty or missing arrays yield
+// a single view, in which keys referencing their elements are not found.
+func fanOut(jsonm map[string]any, plan *FindingsPlan, resolved map[string]struct{}) []map[string]any {
+	prefix, found := nextWildcard(plan.keys, resolved)
+	if !found {
+		return []map[string]any{jsonm}
+	}
+
+	resolved = maps.Clone(resolved)
+	resolved[prefix] = struct{}{}
+
+	steps := plan.wildcards[prefix]
+
+	withElement := func(element any) map[string]any {
+		view, _ := replaceAt(jsonm, steps, element).(map[string]any)
+
+		return view
+	}
+
+	value, _ := evalQuery(jsonm, steps)
+
+	elements, ok := value.([]any)
+	if !ok || len(elements) == 0 {
+		return fanOut(withElement(map[string]any{}), plan, resolved)
+	}
+
+	var views []map[string]any
+
+	for _, element := range elements {
+		views = append(views, fanOut(withElement(element), plan, resolved)...)
+	}
+
+	return views
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFindingsFromJSONFanOut(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		mappings JSONToFindingsMappings
+		want     []string
+	}{
+		{
+			name:   "array elements",
+			output: `{"rule":"a","message":"m","locations":[{"path":"x.go"},{"path":"y.go"}]}`,
+			mappings: JSONToFindingsMappings{
+				FilePath: JSONMappingInfo{Key: "locations[*].path"},
+			},
+			want: []string{"a x.go 0", "a y.go 0"},
+		},
+		{
+			name:   "nested arrays",
+			output: `{"rule":"a","message":"m","locations":[{"path":"x.go","lines":[{"start":1},{"start":2}]},{"path":"y.go","lines":[{"start":3}]}]}`,
+			mappings: JSONToFindingsMappings{
+				FilePath:  JSONMappingInfo{Key: "locations[*].path"},
+				StartLine: JSONMappingInfo{Key: "locations[*].lines[*].start"},
+			},
+			want: []string{"a x.go 1", "a x.go 2", "a y.go 3"},
+		},
+		{
+			name:   "cartesian product of arrays",
+			output: `{"rules":[{"id":"a"},{"id":"b"}],"message":"m","files":["x.go","y.go"]}`,
+			mappings: JSONToFindingsMappings{
+				RuleID:   JSONMappingInfo{Key: "rules[*].id"},
+				FilePath: JSONMappingInfo{Key: "files[*]"},
+			},
+			want: []string{"a x.go 0", "a y.go 0", "b x.go 0", "b y.go 0"},
+		},
+		{
+			name:   "empty array",
+			output: `{"rule":"a","message":"m","locations":[]}`,
+			mappings: JSONToFindingsMappings{
+				FilePath: JSONMappingInfo{Key: "locations[*].path", DefaultValue: "."},
+			},
+			want: []string{"a . 0"},
+		},
+		{
+			name:   "missing array",
+			output: `{"rule":"a","message":"m"}`,
+			mappings: JSONToFindingsMappings{
+				FilePath: JSONMappingInfo{Key: "locations[*].path", DefaultValue: "."},
+			},
+			want: []string{"a . 0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			mappings := test.mappings
+			base := fullMappings()
+			mappings.ToolName = base.ToolName
+			mappings.Level = base.Level
+			mappings.Message = base.Message
+
+			if mappings.RuleID.Key == "" {
+				mappings.RuleID = base.RuleID
+			}
+
+			findings, err := FindingsFromJSON(test.output, JSONInfos{Type: "object", Mappings: mappings})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, finding := range findings {
+				got = append(got, finding.RuleID+" "+finding.FilePath+" "+strconv.Itoa(finding.StartLine))
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findings = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMappingsKeys(t *testing.T) {
+	t.Parallel()
+
+	mappings := JSONToFindingsMappings{
+		ToolName: JSONMappingInfo{OverrideValue: "tool"},
+		RuleID:   JSONMappingInfo{Key: "rule", Suffix: &JSONMappingInfo{Key: "sub"}},
+		Message:  JSONMappingInfo{Key: "message", OverrideValue: "constant"},
+		Fix: JSONFixMappings{
+			BaseArrayKey: "edits[*]",
+			Text:         JSONMappingInfo{Key: "text"},
+		},
+	}
+
+	// Override values take precedence over keys, and replacements keys are relative to their array
+	want := []string{"rule", "sub", "edits[*]"}
+	if got := mappingsKeys(mappings); !reflect.DeepEqual(got, want) {
+		t.Errorf("mappingsKeys() = %v, want %v", got, want)
+	}
+}
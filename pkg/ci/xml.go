@@ -0,0 +1,232 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type junitInputCase struct {
+	Name      string              `xml:"name,attr"`
+	Classname string              `xml:"classname,attr"`
+	File      string              `xml:"file,attr"`
+	Line      string              `xml:"line,attr"`
+	Failures  []junitInputFailure `xml:"failure"`
+	Errors    []junitInputFailure `xml:"error"`
+}
+
+type junitInputFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Set value of given object if not empty, so that default values of mappings apply otherwise. Integer values are
+// converted, as XML attributes are untyped.
+func setXMLValue(object map[string]any, key string, value string) {
+	if value == "" {
+		return
+	}
+
+	num, err := strconv.Atoi(value)
+	if err == nil {
+		object[key] = num
+	} else {
+		object[key] = value
+	}
+}
+
+// Mappings which key defaults to the Checkstyle key of same name, when neither key nor override value is set.
+func checkstyleKeysMappings(mappings *JSONToFindingsMappings) map[string]*JSONMappingInfo {
+	return map[string]*JSONMappingInfo{
+		"file":     &mappings.FilePath,
+		"line":     &mappings.StartLine,
+		"column":   &mappings.StartCol,
+		"severity": &mappings.Level,
+		"message":  &mappings.Message,
+		"source":   &mappings.RuleID,
+	}
+}
+
+// Set keys of JUnit mappings which neither key nor override value is set, level defaulting to error.
+func setJUnitDefaults(mappings *JSONToFindingsMappings) {
+	setDefaultKeys(map[string]*JSONMappingInfo{
+		"file":    &mappings.FilePath,
+		"line":    &mappings.StartLine,
+		"name":    &mappings.RuleID,
+		"message": &mappings.Message,
+	}, func(string) bool { return true })
+
+	if mappings.Level.Key == "" && mappings.Level.OverrideValue == "" && mappings.Level.DefaultValue == "" {
+		mappings.Level.DefaultValue = "error"
+	}
+}
+
+// Value of given attribute of an element, empty if not set.
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// Parse findings from a Checkstyle XML document as it is written, each error element being converted to a JSON object
+// with keys file, line, column, severity, message and source, which are used by mappings which neither key nor override
+// value is set.
+func decodeCheckstyleXMLFindings(r io.Reader, plan *FindingsPlan, emit func(Finding)) error {
+	decoder := xml.NewDecoder(r)
+	rootRead := false
+	fileName := ""
+
+	for {
+		token, err := decoder.Token()
+		// Output may be empty if there is no finding
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("error unmarshalling checkstyle xml: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if !rootRead {
+				if element.Name.Local != "checkstyle" {
+					return fmt.Errorf("error unmarshalling checkstyle xml: root element is %s: %w", element.Name.Local, ErrInvalidFormat)
+				}
+
+				rootRead = true
+
+				continue
+			}
+
+			switch element.Name.Local {
+			case "file":
+				fileName = xmlAttr(element, "name")
+			case "error":
+				var e checkstyleError
+
+				err = decoder.DecodeElement(&e, &element)
+				if err != nil {
+					return fmt.Errorf("error unmarshalling checkstyle xml: %w", err)
+				}
+
+				object := map[string]any{}
+
+				setXMLValue(object, "file", fileName)
+				setXMLValue(object, "line", strconv.Itoa(e.Line))
+				setXMLValue(object, "column", strconv.Itoa(e.Column))
+				setXMLValue(object, "severity", e.Severity)
+				setXMLValue(object, "message", e.Message)
+				setXMLValue(object, "source", e.Source)
+
+				err = emitObjectFindings(object, plan, emit)
+				if err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if element.Name.Local == "file" {
+				fileName = ""
+			}
+		}
+	}
+}
+
+// Parse findings from a JUnit XML document as it is written, each failure or error of test cases being converted to a
+// JSON object with keys suite, classname, name, file, line, kind (`failure` or `error`), type, message (defaulting to
+// text) and text. Passing and skipped test cases are not findings. Mappings which neither key nor override value is set
+// use file, line, name and message keys, level defaulting to error.
+func decodeJUnitXMLFindings(r io.Reader, plan *FindingsPlan, emit func(Finding)) error {
+	decoder := xml.NewDecoder(r)
+	// Names of suites enclosing the current element, root being either `testsuites` or a single `testsuite`
+	suites := []string{}
+
+	for {
+		token, err := decoder.Token()
+		// Output may be empty if there is no finding
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("error unmarshalling junit xml: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "testsuites", "testsuite":
+				suites = append(suites, xmlAttr(element, "name"))
+			case "testcase":
+				var testCase junitInputCase
+
+				err = decoder.DecodeElement(&testCase, &element)
+				if err != nil {
+					return fmt.Errorf("error unmarshalling junit xml: %w", err)
+				}
+
+				suite := ""
+				if len(suites) > 0 {
+					suite = suites[len(suites)-1]
+				}
+
+				err = emitJUnitCaseFindings(suite, &testCase, plan, emit)
+				if err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if (element.Name.Local == "testsuites" || element.Name.Local == "testsuite") && len(suites) > 0 {
+				suites = suites[:len(suites)-1]
+			}
+		}
+	}
+}
+
+// Parse findings from failures and errors of a JUnit test case, see decodeJUnitXMLFindings.
+func emitJUnitCaseFindings(suite string, testCase *junitInputCase, plan *FindingsPlan, emit func(Finding)) error {
+	for _, kinded := range []struct {
+		kind     string
+		failures []junitInputFailure
+	}{
+		{kind: "failure", failures: testCase.Failures},
+		{kind: "error", failures: testCase.Errors},
+	} {
+		for _, failure := range kinded.failures {
+			object := map[string]any{}
+
+			message := failure.Message
+			if message == "" {
+				message = strings.TrimSpace(failure.Text)
+			}
+
+			setXMLValue(object, "suite", suite)
+			setXMLValue(object, "classname", testCase.Classname)
+			setXMLValue(object, "name", testCase.Name)
+			setXMLValue(object, "file", testCase.File)
+			setXMLValue(object, "line", testCase.Line)
+			setXMLValue(object, "kind", kinded.kind)
+			setXMLValue(object, "type", failure.Type)
+			setXMLValue(object, "message", message)
+			setXMLValue(object, "text", strings.TrimSpace(failure.Text))
+
+			err := emitObjectFindings(object, plan, emit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
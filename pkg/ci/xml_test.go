@@ -0,0 +1,194 @@
+// Copyright 2025 kemadev
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFindingsFromXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		jsonInfo JSONInfos
+		output   string
+		want     []Finding
+	}{
+		{
+			name: "checkstyle default keys",
+			jsonInfo: JSONInfos{
+				Type:     "checkstyle-xml",
+				Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "ktlint"}},
+			},
+			output: `<?xml version="1.0" encoding="utf-8"?>
+<checkstyle version="8.0">
+  <file name="src/Main.kt">
+    <error line="3" column="5" severity="warning" message="Unused import" source="standard:no-unused-imports"/>
+    <error line="10" severity="error" message="Missing newline" source="standard:final-newline"/>
+  </file>
+  <file name="src/Clean.kt"/>
+</checkstyle>`,
+			want: []Finding{
+				{ToolName: "ktlint", RuleID: "standard:no-unused-imports", Level: "warning", FilePath: "src/Main.kt", StartLine: 3, StartCol: 5, Message: "Unused import"},
+				{ToolName: "ktlint", RuleID: "standard:final-newline", Level: "error", FilePath: "src/Main.kt", StartLine: 10, Message: "Missing newline"},
+			},
+		},
+		{
+			name: "checkstyle overridden mappings",
+			jsonInfo: JSONInfos{
+				Type: "checkstyle-xml",
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "tool"},
+					Level:    JSONMappingInfo{OverrideValue: "notice"},
+					RuleID:   JSONMappingInfo{Key: "message"},
+				},
+			},
+			output: `<checkstyle><file name="a.js"><error line="1" severity="error" message="no-var"/></file></checkstyle>`,
+			want: []Finding{
+				{ToolName: "tool", RuleID: "no-var", Level: "notice", FilePath: "a.js", StartLine: 1, Message: "no-var"},
+			},
+		},
+		{
+			name: "junit testsuites root with nested suites",
+			jsonInfo: JSONInfos{
+				Type:     "junit-xml",
+				Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "pytest"}},
+			},
+			output: `<testsuites>
+  <testsuite name="outer">
+    <testcase name="test_pass" classname="tests.a"/>
+    <testcase name="test_skip" classname="tests.a"><skipped/></testcase>
+    <testcase name="test_fail" classname="tests.a" file="tests/a.py" line="12">
+      <failure message="assert 1 == 2" type="AssertionError">traceback</failure>
+    </testcase>
+    <testsuite name="inner">
+      <testcase name="test_error" classname="tests.b" file="tests/b.py">
+        <error type="RuntimeError">
+          fixture failed
+        </error>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+			want: []Finding{
+				{ToolName: "pytest", RuleID: "test_fail", Level: "error", FilePath: "tests/a.py", StartLine: 12, Message: "assert 1 == 2"},
+				{ToolName: "pytest", RuleID: "test_error", Level: "error", FilePath: "tests/b.py", Message: "fixture failed"},
+			},
+		},
+		{
+			name: "junit suite of test cases",
+			jsonInfo: JSONInfos{
+				Type: "junit-xml",
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "tool"},
+					Category: JSONMappingInfo{Key: "suite"},
+				},
+			},
+			output: `<testsuites name="all">
+  <testsuite name="outer">
+    <testsuite name="inner">
+      <testcase name="first"><failure message="first failed"/></testcase>
+    </testsuite>
+    <testcase name="second"><failure message="second failed"/></testcase>
+  </testsuite>
+  <testcase name="third"><failure message="third failed"/></testcase>
+</testsuites>`,
+			want: []Finding{
+				{ToolName: "tool", RuleID: "first", Level: "error", Message: "first failed", Category: "inner"},
+				{ToolName: "tool", RuleID: "second", Level: "error", Message: "second failed", Category: "outer"},
+				{ToolName: "tool", RuleID: "third", Level: "error", Message: "third failed", Category: "all"},
+			},
+		},
+		{
+			name: "junit single testsuite root with kind mapped to level",
+			jsonInfo: JSONInfos{
+				Type: "junit-xml",
+				Mappings: JSONToFindingsMappings{
+					ToolName: JSONMappingInfo{OverrideValue: "jest"},
+					RuleID:   JSONMappingInfo{Key: "classname"},
+					Level:    JSONMappingInfo{Key: "kind"},
+					FilePath: JSONMappingInfo{DefaultValue: "."},
+				},
+			},
+			output: `<testsuite name="suite">
+  <testcase name="renders" classname="App">
+    <failure message="expected true">details</failure>
+    <error message="timeout"/>
+  </testcase>
+</testsuite>`,
+			want: []Finding{
+				{ToolName: "jest", RuleID: "App", Level: "failure", FilePath: ".", Message: "expected true"},
+				{ToolName: "jest", RuleID: "App", Level: "error", FilePath: ".", Message: "timeout"},
+			},
+		},
+		{
+			name: "junit without failure",
+			jsonInfo: JSONInfos{
+				Type:     "junit-xml",
+				Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}},
+			},
+			output: `<testsuite name="suite"><testcase name="ok"/></testsuite>`,
+			want:   []Finding{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := CompileJSONInfos(test.jsonInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := plan.FindingsFromJSON(test.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) == 0 && len(test.want) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findings =\n%+v\nwant\n%+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindingsFromXMLInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, inputType := range []string{"checkstyle-xml", "junit-xml"} {
+		plan, err := CompileJSONInfos(JSONInfos{
+			Type:     inputType,
+			Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = plan.FindingsFromJSON("<unterminated")
+		if err == nil {
+			t.Errorf("%s: parsing invalid XML succeeded", inputType)
+		}
+	}
+
+	plan, err := CompileJSONInfos(JSONInfos{
+		Type:     "checkstyle-xml",
+		Mappings: JSONToFindingsMappings{ToolName: JSONMappingInfo{OverrideValue: "tool"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = plan.FindingsFromJSON(`<testsuite name="suite"/>`)
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("parsing XML of another format error = %v, want %v", err, ErrInvalidFormat)
+	}
+}
@@ -0,0 +1,36 @@
+(function () {
+	const tool = document.getElementById("filter-tool");
+	const level = document.getElementById("filter-level");
+	const text = document.getElementById("filter-text");
+
+	function applyFilters() {
+		const needle = text.value.toLowerCase();
+
+		document.querySelectorAll("section.tool").forEach(function (section) {
+			let sectionVisible = false;
+
+			section.querySelectorAll("details.file").forEach(function (file) {
+				const path = file.querySelector("summary").textContent.toLowerCase();
+				let fileVisible = false;
+
+				file.querySelectorAll("tr.finding").forEach(function (row) {
+					const visible = (tool.value === "" || section.dataset.tool === tool.value) &&
+						(level.value === "" || row.dataset.level === level.value) &&
+						(needle === "" || path.includes(needle) || row.textContent.toLowerCase().includes(needle));
+
+					row.classList.toggle("hidden", !visible);
+					fileVisible = fileVisible || visible;
+				});
+
+				file.classList.toggle("hidden", !fileVisible);
+				sectionVisible = sectionVisible || fileVisible;
+			});
+
+			section.classList.toggle("hidden", !sectionVisible);
+		});
+	}
+
+	[tool, level, text].forEach(function (input) {
+		input.addEventListener("input", applyFilters);
+	});
+})();